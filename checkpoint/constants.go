@@ -0,0 +1,13 @@
+package checkpoint
+
+const (
+	consumerField          = "consumer"
+	tokenField             = "token"
+	updatedAtField         = "updated_at"
+	databaseMissingMessage = "Database of the checkpoints is not defined"
+	tableMissingMessage    = "Table of the checkpoints is not defined"
+	consumerMissingMessage = "Name of the consumer is not defined"
+	timeoutMessage         = "Invalid timeout: %d. It must be higher than 0"
+	invalidTokenMessage    = "Invalid resume token of the consumer %s: %v"
+	alreadyAckedMessage    = "Delivery %d is already acknowledged"
+)
@@ -0,0 +1,120 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Consumer is the structure that reads a change stream from the last checkpoint of its name and saves the
+// checkpoints as the events are acknowledged. The delivery is at-least-once: the events not acknowledged
+// before a restart are delivered again
+// store: It is the Store of the checkpoints
+// name: It is the name of the consumer
+// stream: It is the change stream, opened after the last checkpoint
+// mutex: It protects sequence and pending
+// sequence: It is the number of the next Delivery
+// pending: They are the deliveries not committed yet, in the order of the stream
+type Consumer struct {
+	store    *Store
+	name     string
+	stream   database.ChangeStream
+	mutex    sync.Mutex
+	sequence uint64
+	pending  []*Delivery
+}
+
+// Delivery is an event of the stream that must be acknowledged once it is processed
+// Event: It is the change of the table
+// Sequence: It is the position of the Delivery in the Consumer
+type Delivery struct {
+	Event    database.ChangeEvent
+	Sequence uint64
+	consumer *Consumer
+	acked    bool
+}
+
+// StartConsumer opens the change stream of the table from the last checkpoint of the consumer, or from now
+// if it has never saved one
+// ctx: It is the context to open the stream
+// store: It is the Store of the checkpoints
+// watcher: It is the Manager that notifies the changes (see database.Watcher)
+// name: It is the name of the consumer. Each consumer has its own checkpoint
+// table: It is the table to watch
+// filter: It is the filter of the changes
+// It returns the Consumer and an error
+func StartConsumer(ctx context.Context, store *Store, watcher database.Watcher, name, table string, filter map[string]interface{}) (*Consumer, error) {
+	token, err := store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	var stream database.ChangeStream
+	if token == nil {
+		stream, err = watcher.Watch(ctx, table, filter)
+	} else {
+		stream, err = watcher.WatchFrom(ctx, table, filter, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Consumer{store: store, name: name, stream: stream}, nil
+}
+
+// Next returns the next event of the stream, which must be acknowledged with Delivery.Ack once it is processed
+// ctx: It is the context to wait for the event
+// It returns the Delivery and an error
+func (consumer *Consumer) Next(ctx context.Context) (*Delivery, error) {
+	event, err := consumer.stream.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+	delivery := &Delivery{Event: event, Sequence: consumer.sequence, consumer: consumer}
+	consumer.sequence++
+	consumer.pending = append(consumer.pending, delivery)
+	return delivery, nil
+}
+
+// Pending returns the number of deliveries that are not committed yet
+func (consumer *Consumer) Pending() int {
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+	return len(consumer.pending)
+}
+
+// Close stops the stream. The deliveries not acknowledged are delivered again by the next Consumer with the same name
+func (consumer *Consumer) Close() error {
+	return consumer.stream.Close()
+}
+
+// Ack marks the Delivery as processed. The checkpoint only moves forward when all the previous deliveries are
+// acknowledged too, so the deliveries can be acknowledged in any order from many goroutines
+// It returns an error if the checkpoint can not be saved. In that case, the Delivery can be acknowledged again
+func (delivery *Delivery) Ack() error {
+	consumer := delivery.consumer
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+	if delivery.acked {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(alreadyAckedMessage, delivery.Sequence)}
+	}
+	delivery.acked = true
+
+	committed := 0
+	for committed < len(consumer.pending) && consumer.pending[committed].acked {
+		committed++
+	}
+	if committed == 0 {
+		return nil
+	}
+	if err := consumer.store.Save(consumer.name, consumer.pending[committed-1].Event.ResumeToken); err != nil {
+		delivery.acked = false
+		return err
+	}
+	consumer.pending = consumer.pending[committed:]
+	return nil
+}
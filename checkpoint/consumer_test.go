@@ -0,0 +1,129 @@
+package checkpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamMock struct {
+	events []database.ChangeEvent
+	token  []byte
+}
+
+func (stream *streamMock) Next(ctx context.Context) (database.ChangeEvent, error) {
+	if len(stream.events) == 0 {
+		return database.ChangeEvent{}, context.DeadlineExceeded
+	}
+	event := stream.events[0]
+	stream.events = stream.events[1:]
+	return event, nil
+}
+
+func (stream *streamMock) ResumeToken() []byte {
+	return stream.token
+}
+
+func (stream *streamMock) Close() error {
+	return nil
+}
+
+// watcherMock delivers the events after the resume token
+type watcherMock struct {
+	events []database.ChangeEvent
+	from   []byte
+}
+
+func (watcher *watcherMock) Watch(ctx context.Context, table string, filter map[string]interface{}) (database.ChangeStream, error) {
+	return &streamMock{events: watcher.events}, nil
+}
+
+func (watcher *watcherMock) WatchFrom(ctx context.Context, table string, filter map[string]interface{}, resumeToken []byte) (database.ChangeStream, error) {
+	watcher.from = resumeToken
+	for i, event := range watcher.events {
+		if string(event.ResumeToken) == string(resumeToken) {
+			return &streamMock{events: watcher.events[i+1:], token: resumeToken}, nil
+		}
+	}
+	return &streamMock{token: resumeToken}, nil
+}
+
+func initializeWatcher() *watcherMock {
+	return &watcherMock{events: []database.ChangeEvent{
+		{Type: database.ChangeInsert, ResumeToken: []byte("1")},
+		{Type: database.ChangeUpdate, ResumeToken: []byte("2")},
+		{Type: database.ChangeDelete, ResumeToken: []byte("3")},
+	}}
+}
+
+func TestConsumerAckInOrderSuccess(t *testing.T) {
+	mock, _ := initializeMock()
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+	watcher := initializeWatcher()
+
+	consumer, err := StartConsumer(context.Background(), store, watcher, consumerTest, "test", nil)
+	assert.NoError(t, err)
+	delivery, err := consumer.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, database.ChangeInsert, delivery.Event.Type)
+	assert.NoError(t, delivery.Ack())
+	assert.NoError(t, consumer.Close())
+
+	consumer, err = StartConsumer(context.Background(), store, watcher, consumerTest, "test", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), watcher.from)
+	delivery, err = consumer.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, database.ChangeUpdate, delivery.Event.Type)
+}
+
+func TestConsumerAckOutOfOrderSuccess(t *testing.T) {
+	mock, _ := initializeMock()
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+
+	consumer, err := StartConsumer(context.Background(), store, initializeWatcher(), consumerTest, "test", nil)
+	assert.NoError(t, err)
+	var deliveries []*Delivery
+	for i := 0; i < 3; i++ {
+		delivery, err := consumer.Next(context.Background())
+		assert.NoError(t, err)
+		deliveries = append(deliveries, delivery)
+	}
+
+	assert.NoError(t, deliveries[1].Ack())
+	token, err := store.Load(consumerTest)
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+	assert.Equal(t, 3, consumer.Pending())
+
+	assert.NoError(t, deliveries[0].Ack())
+	token, err = store.Load(consumerTest)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("2"), token)
+	assert.Equal(t, 1, consumer.Pending())
+
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, deliveries[0].Ack(), &myErr)
+}
+
+func TestConsumerFailedSave(t *testing.T) {
+	mock, _ := initializeMock()
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+	consumer, err := StartConsumer(context.Background(), store, initializeWatcher(), consumerTest, "test", nil)
+	assert.NoError(t, err)
+	delivery, err := consumer.Next(context.Background())
+	assert.NoError(t, err)
+
+	mock.UpdateOneFunc = func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+		return nil, &libraryErrors.ConnectionError{Db: "test"}
+	}
+	var myErr *libraryErrors.ConnectionError
+	assert.ErrorAs(t, delivery.Ack(), &myErr)
+	assert.Equal(t, 1, consumer.Pending())
+}
@@ -0,0 +1,96 @@
+package checkpoint
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Store is the structure that saves the resume tokens of the consumers in a table, one document per consumer
+// with the fields consumer, token (base64) and updated_at. A unique index on consumer is recommended
+// database: It is the database with the table of the checkpoints
+// table: It is the name of the table
+// timeout: It is the timeout of the operations in seconds
+type Store struct {
+	database database.DatabaseInterface
+	table    string
+	timeout  int64
+}
+
+// CreateStore is the constructor for the Store
+// db: It is the database with the table of the checkpoints. It must be connected
+// table: It is the name of the table
+// timeout: It is the timeout of the operations in seconds
+// It returns the Store instance and an error
+func CreateStore(db database.DatabaseInterface, table string, timeout int64) (*Store, error) {
+	if db == nil {
+		return nil, &libraryErrors.InputError{Message: databaseMissingMessage}
+	}
+	if table == "" {
+		return nil, &libraryErrors.InputError{Message: tableMissingMessage}
+	}
+	if timeout < 1 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
+	}
+	return &Store{database: db, table: table, timeout: timeout}, nil
+}
+
+// Load returns the last resume token saved for the consumer
+// consumer: It is the name of the consumer
+// It returns the token, which is nil if the consumer has never saved one, and an error
+func (store *Store) Load(consumer string) ([]byte, error) {
+	if consumer == "" {
+		return nil, &libraryErrors.InputError{Message: consumerMissingMessage}
+	}
+	document, err := store.database.FindOne(store.table, store.timeout, map[string]interface{}{consumerField: consumer})
+	var notExistError *libraryErrors.NotExistError
+	if errors.As(err, &notExistError) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, _ := document[tokenField].(string)
+	token, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(invalidTokenMessage, consumer, err)}
+	}
+	return token, nil
+}
+
+// Save saves the resume token of the consumer, replacing the previous one
+// consumer: It is the name of the consumer
+// token: It is the resume token of the last event processed
+// It returns an error
+func (store *Store) Save(consumer string, token []byte) error {
+	if consumer == "" {
+		return &libraryErrors.InputError{Message: consumerMissingMessage}
+	}
+	filter := map[string]interface{}{consumerField: consumer}
+	data := map[string]interface{}{
+		tokenField:     base64.StdEncoding.EncodeToString(token),
+		updatedAtField: time.Now().UTC(),
+	}
+
+	_, err := store.database.UpdateOne(store.table, store.timeout, filter, data)
+	var notExistError *libraryErrors.NotExistError
+	if !errors.As(err, &notExistError) {
+		return err
+	}
+
+	document := map[string]interface{}{consumerField: consumer}
+	for key, value := range data {
+		document[key] = value
+	}
+	_, err = store.database.InsertOne(store.table, store.timeout, document)
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	if errors.As(err, &alreadyExistError) {
+		_, err = store.database.UpdateOne(store.table, store.timeout, filter, data)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	timeoutTest  = 5
+	tableTest    = "checkpoints"
+	consumerTest = "consumer"
+)
+
+// initializeMock creates a database that keeps the documents in memory, indexed by consumer
+func initializeMock() (*database.DatabaseInterfaceMock, map[string]map[string]interface{}) {
+	documents := make(map[string]map[string]interface{})
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			document, found := documents[filter[consumerField].(string)]
+			if !found {
+				return nil, &libraryErrors.NotExistError{Message: "test"}
+			}
+			return document, nil
+		},
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			documents[data[consumerField].(string)] = data
+			return data, nil
+		},
+		UpdateOneFunc: func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+			document, found := documents[filter[consumerField].(string)]
+			if !found {
+				return nil, &libraryErrors.NotExistError{Message: "test"}
+			}
+			for key, value := range newData.(map[string]interface{}) {
+				document[key] = value
+			}
+			return document, nil
+		},
+	}
+	return mock, documents
+}
+
+func TestStoreSuccess(t *testing.T) {
+	mock, documents := initializeMock()
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+
+	token, err := store.Load(consumerTest)
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	assert.NoError(t, store.Save(consumerTest, []byte("token1")))
+	assert.NoError(t, store.Save(consumerTest, []byte("token2")))
+	assert.Len(t, documents, 1)
+	token, err = store.Load(consumerTest)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token2"), token)
+}
+
+func TestStoreFailedInvalidToken(t *testing.T) {
+	mock, documents := initializeMock()
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+	documents[consumerTest] = map[string]interface{}{tokenField: "%%%"}
+
+	_, err = store.Load(consumerTest)
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestCreateStoreFailed(t *testing.T) {
+	mock, _ := initializeMock()
+	var myErr *libraryErrors.InputError
+
+	_, err := CreateStore(nil, tableTest, timeoutTest)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = CreateStore(mock, "", timeoutTest)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = CreateStore(mock, tableTest, 0)
+	assert.ErrorAs(t, err, &myErr)
+
+	store, err := CreateStore(mock, tableTest, timeoutTest)
+	assert.NoError(t, err)
+	_, err = store.Load("")
+	assert.ErrorAs(t, err, &myErr)
+	assert.ErrorAs(t, store.Save("", nil), &myErr)
+}
@@ -0,0 +1,362 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// State is the state of the Breaker
+type State int
+
+const (
+	// StateClosed lets every operation reach the database
+	StateClosed State = iota
+	// StateHalfOpen lets a limited number of probes reach the database
+	StateHalfOpen
+	// StateOpen rejects every operation without reaching the database
+	StateOpen
+)
+
+// String returns the name of the state
+func (state State) String() string {
+	switch state {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("unknown state: %d", int(state))
+	}
+}
+
+// Config is the structure to define the behaviour of the Breaker
+// FailureRatio: Ratio of failed operations (between 0 and 1) that trips the Breaker
+// MinRequests: Minimum number of operations in the closed state before the ratio is checked
+// Interval: Period after which the counts of the closed state are cleared. 0 means never
+// OpenTimeout: Time that the Breaker stays open before moving to half-open
+// HalfOpenMaxRequests: Number of probes allowed in half-open. If all of them succeed, the Breaker closes. 0 means 1
+// OnStateChange: Optional function called every time the state changes. It is called after the Breaker is unlocked,
+// so it can call State and Counts
+type Config struct {
+	FailureRatio        float64
+	MinRequests         uint32
+	Interval            time.Duration
+	OpenTimeout         time.Duration
+	HalfOpenMaxRequests uint32
+	OnStateChange       func(from, to State)
+}
+
+// Counts contains the number of operations executed in the current state of the Breaker
+type Counts struct {
+	Requests             uint32
+	TotalSuccesses       uint32
+	TotalFailures        uint32
+	ConsecutiveSuccesses uint32
+	ConsecutiveFailures  uint32
+}
+
+// Breaker is a circuit breaker implementing the DatabaseInterface around another DatabaseInterface
+// database: It is the database protected by the Breaker
+// config: It is the configuration of the Breaker
+// mutex: It protects the state, the counts and the expiry
+// generation: It is increased on every state change to discard results from old states
+// expiry: It is the moment when the current counts (closed) or the open state expire
+// now: It is the clock of the Breaker
+// transitions: They are the state changes pending to be notified to OnStateChange when the mutex is unlocked
+type Breaker struct {
+	database    database.DatabaseInterface
+	config      Config
+	mutex       sync.Mutex
+	state       State
+	counts      Counts
+	generation  uint64
+	expiry      time.Time
+	now         func() time.Time
+	transitions [][2]State
+}
+
+// CreateBreaker is the constructor for the Breaker
+// db: It is the database to protect
+// config: It is the configuration of the Breaker
+// It returns the Breaker instance and an error
+func CreateBreaker(db database.DatabaseInterface, config Config) (*Breaker, error) {
+	if db == nil {
+		return nil, &libraryErrors.InputError{Message: databaseMissingMessage}
+	}
+	if config.FailureRatio <= 0 || config.FailureRatio > 1 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(failureRatioMessage, config.FailureRatio)}
+	}
+	if config.OpenTimeout <= 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(openTimeoutMessage, config.OpenTimeout)}
+	}
+	if config.Interval < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(intervalMessage, config.Interval)}
+	}
+	if config.HalfOpenMaxRequests == 0 {
+		config.HalfOpenMaxRequests = 1
+	}
+	breaker := &Breaker{database: db, config: config, now: time.Now}
+	breaker.toNewGeneration(breaker.now())
+	return breaker, nil
+}
+
+// State returns the current state of the Breaker
+func (breaker *Breaker) State() State {
+	breaker.mutex.Lock()
+	defer breaker.unlock()
+	state, _ := breaker.currentState(breaker.now())
+	return state
+}
+
+// Counts returns the counts of the current state of the Breaker
+func (breaker *Breaker) Counts() Counts {
+	breaker.mutex.Lock()
+	defer breaker.unlock()
+	breaker.currentState(breaker.now())
+	return breaker.counts
+}
+
+// ConnectDb is the function to connect the protected database
+func (breaker *Breaker) ConnectDb(dbURI, dbName string, timeout int64) error {
+	return breaker.execute(func() error {
+		return breaker.database.ConnectDb(dbURI, dbName, timeout)
+	})
+}
+
+// DisconnectDb is the function to disconnect the protected database. It is never rejected by the Breaker
+func (breaker *Breaker) DisconnectDb() error {
+	return breaker.database.DisconnectDb()
+}
+
+// InsertOne is the function to insert a document through the Breaker
+func (breaker *Breaker) InsertOne(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.InsertOne(table, timeout, data)
+		return err
+	})
+	return result, err
+}
+
+// InsertMany is the function to insert many documents through the Breaker
+func (breaker *Breaker) InsertMany(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.InsertMany(table, timeout, data)
+		return err
+	})
+	return result, err
+}
+
+// FindOne is the function to find a document through the Breaker
+func (breaker *Breaker) FindOne(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.FindOne(table, timeout, filter)
+		return err
+	})
+	return result, err
+}
+
+// FindMany is the function to find many documents through the Breaker
+func (breaker *Breaker) FindMany(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.FindMany(table, timeout, filter)
+		return err
+	})
+	return result, err
+}
+
+// UpdateOne is the function to update a document through the Breaker
+func (breaker *Breaker) UpdateOne(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.UpdateOne(table, timeout, filter, newData)
+		return err
+	})
+	return result, err
+}
+
+// UpdateMany is the function to update many documents through the Breaker
+func (breaker *Breaker) UpdateMany(table string, timeout int64, filter map[string]interface{}, newData interface{}) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.UpdateMany(table, timeout, filter, newData)
+		return err
+	})
+	return result, err
+}
+
+// DeleteOne is the function to delete a document through the Breaker
+func (breaker *Breaker) DeleteOne(table string, timeout int64, filter map[string]interface{}) error {
+	return breaker.execute(func() error {
+		return breaker.database.DeleteOne(table, timeout, filter)
+	})
+}
+
+// DeleteMany is the function to delete many documents through the Breaker
+func (breaker *Breaker) DeleteMany(table string, timeout int64, filter map[string]interface{}) (int, error) {
+	var result int
+	err := breaker.execute(func() error {
+		var err error
+		result, err = breaker.database.DeleteMany(table, timeout, filter)
+		return err
+	})
+	return result, err
+}
+
+// execute runs the operation if the Breaker allows it and records its result. A panic of the operation is
+// recorded as a failure before it is propagated, so the probes of the half-open state are not lost
+func (breaker *Breaker) execute(operation func() error) error {
+	generation, err := breaker.beforeRequest()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			breaker.afterRequest(generation, false)
+			panic(recovered)
+		}
+	}()
+	err = operation()
+	breaker.afterRequest(generation, !isFailure(err))
+	return err
+}
+
+// beforeRequest checks if a new operation is allowed and counts it
+func (breaker *Breaker) beforeRequest() (uint64, error) {
+	breaker.mutex.Lock()
+	defer breaker.unlock()
+
+	state, generation := breaker.currentState(breaker.now())
+	if state == StateOpen {
+		return generation, &libraryErrors.CircuitOpenError{Message: circuitOpenMessage}
+	} else if state == StateHalfOpen && breaker.counts.Requests >= breaker.config.HalfOpenMaxRequests {
+		return generation, &libraryErrors.CircuitOpenError{Message: tooManyProbesMessage}
+	}
+	breaker.counts.Requests++
+	return generation, nil
+}
+
+// afterRequest records the result of an operation if it belongs to the current generation
+func (breaker *Breaker) afterRequest(before uint64, success bool) {
+	breaker.mutex.Lock()
+	defer breaker.unlock()
+
+	now := breaker.now()
+	state, generation := breaker.currentState(now)
+	if generation != before {
+		return
+	}
+	if success {
+		breaker.onSuccess(state, now)
+	} else {
+		breaker.onFailure(state, now)
+	}
+}
+
+func (breaker *Breaker) onSuccess(state State, now time.Time) {
+	breaker.counts.TotalSuccesses++
+	breaker.counts.ConsecutiveSuccesses++
+	breaker.counts.ConsecutiveFailures = 0
+	if state == StateHalfOpen && breaker.counts.ConsecutiveSuccesses >= breaker.config.HalfOpenMaxRequests {
+		breaker.setState(StateClosed, now)
+	}
+}
+
+func (breaker *Breaker) onFailure(state State, now time.Time) {
+	breaker.counts.TotalFailures++
+	breaker.counts.ConsecutiveFailures++
+	breaker.counts.ConsecutiveSuccesses = 0
+	switch state {
+	case StateClosed:
+		if breaker.counts.Requests >= breaker.config.MinRequests &&
+			float64(breaker.counts.TotalFailures)/float64(breaker.counts.Requests) >= breaker.config.FailureRatio {
+			breaker.setState(StateOpen, now)
+		}
+	case StateHalfOpen:
+		breaker.setState(StateOpen, now)
+	}
+}
+
+// currentState moves the Breaker to the next state or generation if the expiry has been reached
+func (breaker *Breaker) currentState(now time.Time) (State, uint64) {
+	switch breaker.state {
+	case StateClosed:
+		if !breaker.expiry.IsZero() && breaker.expiry.Before(now) {
+			breaker.toNewGeneration(now)
+		}
+	case StateOpen:
+		if breaker.expiry.Before(now) {
+			breaker.setState(StateHalfOpen, now)
+		}
+	}
+	return breaker.state, breaker.generation
+}
+
+func (breaker *Breaker) setState(state State, now time.Time) {
+	if breaker.state == state {
+		return
+	}
+	previous := breaker.state
+	breaker.state = state
+	breaker.toNewGeneration(now)
+	if breaker.config.OnStateChange != nil {
+		breaker.transitions = append(breaker.transitions, [2]State{previous, state})
+	}
+}
+
+// unlock unlocks the mutex and then notifies the pending state changes to OnStateChange
+func (breaker *Breaker) unlock() {
+	transitions := breaker.transitions
+	breaker.transitions = nil
+	breaker.mutex.Unlock()
+	for _, transition := range transitions {
+		breaker.config.OnStateChange(transition[0], transition[1])
+	}
+}
+
+func (breaker *Breaker) toNewGeneration(now time.Time) {
+	breaker.generation++
+	breaker.counts = Counts{}
+
+	var zero time.Time
+	switch breaker.state {
+	case StateClosed:
+		if breaker.config.Interval == 0 {
+			breaker.expiry = zero
+		} else {
+			breaker.expiry = now.Add(breaker.config.Interval)
+		}
+	case StateOpen:
+		breaker.expiry = now.Add(breaker.config.OpenTimeout)
+	default:
+		breaker.expiry = zero
+	}
+}
+
+// isFailure defines which errors count as failures of the database. Errors caused by the input
+// or by the data itself (InputError, NotExistError and AlreadyExistError) do not trip the Breaker
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var inputError *libraryErrors.InputError
+	var notExistError *libraryErrors.NotExistError
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	return !errors.As(err, &inputError) && !errors.As(err, &notExistError) && !errors.As(err, &alreadyExistError)
+}
@@ -0,0 +1,215 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "test"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (clock *fakeClock) now() time.Time {
+	return clock.current
+}
+
+func initializeBreaker(t *testing.T, findErr error) (*Breaker, *fakeClock, *int) {
+	calls := 0
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			calls++
+			if findErr != nil {
+				return nil, findErr
+			}
+			return map[string]interface{}{"test": "test"}, nil
+		},
+		DisconnectDbFunc: func() error {
+			return nil
+		},
+	}
+	breaker, err := CreateBreaker(mock, Config{FailureRatio: 0.5, MinRequests: 2, OpenTimeout: time.Minute})
+	assert.NoError(t, err)
+	clock := &fakeClock{current: time.Now()}
+	breaker.now = clock.now
+	return breaker, clock, &calls
+}
+
+func TestCreateBreakerFailedInvalidConfig(t *testing.T) {
+	mock := new(database.DatabaseInterfaceMock)
+	var myErr *libraryErrors.InputError
+
+	breaker, err := CreateBreaker(mock, Config{FailureRatio: 0, OpenTimeout: time.Second})
+	assert.Nil(t, breaker)
+	assert.ErrorAs(t, err, &myErr)
+
+	breaker, err = CreateBreaker(mock, Config{FailureRatio: 0.5})
+	assert.Nil(t, breaker)
+	assert.ErrorAs(t, err, &myErr)
+
+	breaker, err = CreateBreaker(nil, Config{FailureRatio: 0.5, OpenTimeout: time.Second})
+	assert.Nil(t, breaker)
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestBreakerSuccessClosed(t *testing.T) {
+	breaker, _, calls := initializeBreaker(t, nil)
+
+	result, err := breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"test": "test"}, result)
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.Equal(t, Counts{Requests: 1, TotalSuccesses: 1, ConsecutiveSuccesses: 1}, breaker.Counts())
+	assert.Equal(t, 1, *calls)
+}
+
+func TestBreakerTripsOnFailureRatio(t *testing.T) {
+	breaker, _, calls := initializeBreaker(t, &libraryErrors.ConnectionError{Db: "test"})
+
+	_, err := breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	var connectionErr *libraryErrors.ConnectionError
+	assert.ErrorAs(t, err, &connectionErr)
+	assert.Equal(t, StateClosed, breaker.State())
+
+	_, err = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.ErrorAs(t, err, &connectionErr)
+	assert.Equal(t, StateOpen, breaker.State())
+
+	result, err := breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.CircuitOpenError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestBreakerIgnoresDataErrors(t *testing.T) {
+	breaker, _, _ := initializeBreaker(t, &libraryErrors.NotExistError{Message: "test"})
+
+	for i := 0; i < 5; i++ {
+		_, err := breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+		var myErr *libraryErrors.NotExistError
+		assert.ErrorAs(t, err, &myErr)
+	}
+	assert.Equal(t, StateClosed, breaker.State())
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	var transitions []State
+	findErr := error(&libraryErrors.ConnectionError{Db: "test"})
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			return nil, findErr
+		},
+	}
+	breaker, err := CreateBreaker(mock, Config{
+		FailureRatio: 1,
+		OpenTimeout:  time.Minute,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, to)
+		},
+	})
+	assert.NoError(t, err)
+	clock := &fakeClock{current: time.Now()}
+	breaker.now = clock.now
+
+	_, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Equal(t, StateOpen, breaker.State())
+
+	clock.current = clock.current.Add(2 * time.Minute)
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	_, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Equal(t, StateOpen, breaker.State())
+
+	clock.current = clock.current.Add(2 * time.Minute)
+	findErr = nil
+	_, err = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.Equal(t, []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}, transitions)
+}
+
+func TestBreakerIntervalClearsCounts(t *testing.T) {
+	mock := &database.DatabaseInterfaceMock{
+		DeleteOneFunc: func(table string, timeout int64, filter map[string]interface{}) error {
+			return &libraryErrors.ClientError{Message: "test"}
+		},
+	}
+	breaker, err := CreateBreaker(mock, Config{FailureRatio: 0.5, MinRequests: 2, Interval: time.Minute, OpenTimeout: time.Minute})
+	assert.NoError(t, err)
+	clock := &fakeClock{current: time.Now()}
+	breaker.now = clock.now
+
+	_ = breaker.DeleteOne(tableTest, timeoutTest, map[string]interface{}{})
+	clock.current = clock.current.Add(2 * time.Minute)
+	assert.Equal(t, Counts{}, breaker.Counts())
+	_ = breaker.DeleteOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Equal(t, StateClosed, breaker.State())
+}
+
+func TestBreakerDisconnectDbWhenOpen(t *testing.T) {
+	breaker, _, _ := initializeBreaker(t, &libraryErrors.ConnectionError{Db: "test"})
+	_, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	_, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Equal(t, StateOpen, breaker.State())
+
+	assert.NoError(t, breaker.DisconnectDb())
+}
+
+func TestBreakerOnStateChangeReadsBreaker(t *testing.T) {
+	var breaker *Breaker
+	var states []State
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			return nil, &libraryErrors.ConnectionError{Db: "test"}
+		},
+	}
+	breaker, err := CreateBreaker(mock, Config{
+		FailureRatio: 1,
+		OpenTimeout:  time.Minute,
+		OnStateChange: func(from, to State) {
+			states = append(states, breaker.State())
+			_ = breaker.Counts()
+		},
+	})
+	assert.NoError(t, err)
+
+	_, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.Equal(t, []State{StateOpen}, states)
+}
+
+func TestBreakerHalfOpenProbePanics(t *testing.T) {
+	panics := true
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			if panics {
+				panic("test")
+			}
+			return map[string]interface{}{"test": "test"}, nil
+		},
+	}
+	breaker, err := CreateBreaker(mock, Config{FailureRatio: 1, OpenTimeout: time.Minute})
+	assert.NoError(t, err)
+	clock := &fakeClock{current: time.Now()}
+	breaker.now = clock.now
+
+	assert.Panics(t, func() { _, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{}) })
+	assert.Equal(t, StateOpen, breaker.State())
+
+	clock.current = clock.current.Add(2 * time.Minute)
+	assert.Panics(t, func() { _, _ = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{}) })
+	assert.Equal(t, StateOpen, breaker.State())
+
+	clock.current = clock.current.Add(2 * time.Minute)
+	panics = false
+	_, err = breaker.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, breaker.State())
+}
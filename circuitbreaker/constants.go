@@ -0,0 +1,10 @@
+package circuitbreaker
+
+const (
+	circuitOpenMessage     = "Circuit breaker is open"
+	tooManyProbesMessage   = "Circuit breaker is half-open and the probe limit has been reached"
+	failureRatioMessage    = "Invalid failure ratio: %v. It must be higher than 0 and lower or equal than 1"
+	openTimeoutMessage     = "Invalid open timeout: %v. It must be higher than 0"
+	intervalMessage        = "Invalid interval: %v. It must be higher or equal than 0"
+	databaseMissingMessage = "Database to protect is not defined"
+)
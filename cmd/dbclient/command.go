@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// command is a subcommand with its arguments already parsed
+// name: It is the name of the subcommand
+// table: It is the table of the operation
+// filter: It is the filter of the documents
+// data: They are the documents to insert or the changes to apply
+// one: It restricts find to the first document
+// many: It extends update and delete to all the documents that match
+type command struct {
+	name   string
+	table  string
+	filter map[string]interface{}
+	data   []map[string]interface{}
+	one    bool
+	many   bool
+}
+
+// parseCommand parses the flags of the subcommand
+// name: It is the name of the subcommand
+// args: They are the arguments after the name
+// stdin: It is the input of the documents when -data is -
+// stderr: It is the output of the usage of the subcommand
+// It returns the command and an InputError if the arguments are not valid
+func parseCommand(name string, args []string, stdin io.Reader, stderr io.Writer) (*command, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	filter := flags.String("filter", "{}", "Extended JSON filter of the documents")
+	data, one, many := new(string), new(bool), new(bool)
+	switch name {
+	case "find":
+		one = flags.Bool("one", false, "Returns only the first document")
+	case "insert":
+		data = flags.String("data", "", "Extended JSON document or array of documents. - reads them from the standard input")
+	case "update":
+		data = flags.String("data", "", "Extended JSON document with the fields to set. - reads it from the standard input")
+		many = flags.Bool("many", false, "Updates all the documents that match")
+	case "delete":
+		many = flags.Bool("many", false, "Deletes all the documents that match")
+	case "count":
+	default:
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(unknownCommandMessage, name)}
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, &libraryErrors.InputError{Message: err.Error()}
+	}
+	if flags.NArg() != 1 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(tableMissingMessage, name)}
+	}
+
+	cmd := &command{name: name, table: flags.Arg(0), one: *one, many: *many}
+	parsedFilter, err := parseDocuments(*filter)
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(filterMessage, err)}
+	}
+	if len(parsedFilter) != 1 {
+		return nil, &libraryErrors.InputError{Message: filterSingleMessage}
+	}
+	cmd.filter = parsedFilter[0]
+
+	if name == "insert" || name == "update" {
+		if *data == "" {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(dataMissingMessage, name)}
+		}
+		if *data == stdinValue {
+			content, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(dataMessage, err)}
+			}
+			*data = string(content)
+		}
+		if cmd.data, err = parseDocuments(*data); err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(dataMessage, err)}
+		}
+		if len(cmd.data) == 0 {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(dataEmptyMessage, name)}
+		}
+		if name == "update" && len(cmd.data) != 1 {
+			return nil, &libraryErrors.InputError{Message: dataSingleMessage}
+		}
+	}
+	return cmd, nil
+}
+
+// execute runs the operation of the command
+// db: It is the database of the operation
+// timeout: It is the timeout of the operation in seconds
+// It returns the documents to write and an error
+func (cmd *command) execute(db database.DatabaseInterface, timeout int64) ([]map[string]interface{}, error) {
+	switch cmd.name {
+	case "find":
+		if cmd.one {
+			document, err := db.FindOne(cmd.table, timeout, cmd.filter)
+			if err != nil {
+				return nil, err
+			}
+			return []map[string]interface{}{document}, nil
+		}
+		return db.FindMany(cmd.table, timeout, cmd.filter)
+	case "insert":
+		if len(cmd.data) == 1 {
+			document, err := db.InsertOne(cmd.table, timeout, cmd.data[0])
+			if err != nil {
+				return nil, err
+			}
+			return []map[string]interface{}{document}, nil
+		}
+		return db.InsertMany(cmd.table, timeout, cmd.data)
+	case "update":
+		if cmd.many {
+			return db.UpdateMany(cmd.table, timeout, cmd.filter, cmd.data[0])
+		}
+		document, err := db.UpdateOne(cmd.table, timeout, cmd.filter, cmd.data[0])
+		if err != nil {
+			return nil, err
+		}
+		return []map[string]interface{}{document}, nil
+	case "delete":
+		deleted := 1
+		var err error
+		if cmd.many {
+			deleted, err = db.DeleteMany(cmd.table, timeout, cmd.filter)
+		} else {
+			err = db.DeleteOne(cmd.table, timeout, cmd.filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []map[string]interface{}{{deletedField: deleted}}, nil
+	default:
+		// DatabaseInterface has no count operation, so the documents are read to count them
+		documents, err := db.FindMany(cmd.table, timeout, cmd.filter)
+		var notExistError *libraryErrors.NotExistError
+		if err != nil && !errors.As(err, &notExistError) {
+			return nil, err
+		}
+		return []map[string]interface{}{{countField: len(documents)}}, nil
+	}
+}
+
+// parseDocuments parses an Extended JSON document or an array of documents
+func parseDocuments(text string) ([]map[string]interface{}, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "[") {
+		var wrapper struct {
+			Documents []map[string]interface{} `bson:"documents"`
+		}
+		if err := bson.UnmarshalExtJSON([]byte(`{"documents":`+text+`}`), false, &wrapper); err != nil {
+			return nil, err
+		}
+		return wrapper.Documents, nil
+	}
+	var document map[string]interface{}
+	if err := bson.UnmarshalExtJSON([]byte(text), false, &document); err != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{document}, nil
+}
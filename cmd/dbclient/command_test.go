@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const timeoutTest = 5
+
+func TestParseCommandSuccess(t *testing.T) {
+	cmd, err := parseCommand("find", []string{"-filter", `{"_id": {"$oid": "5f1d7f3e9d3b2a1c4e8b4567"}}`, "-one", "users"}, nil, io.Discard)
+	assert.NoError(t, err)
+	assert.Equal(t, "users", cmd.table)
+	assert.True(t, cmd.one)
+	assert.IsType(t, primitive.ObjectID{}, cmd.filter["_id"])
+
+	cmd, err = parseCommand("insert", []string{"-data", `[{"a": 1}, {"a": 2}]`, "users"}, nil, io.Discard)
+	assert.NoError(t, err)
+	assert.Len(t, cmd.data, 2)
+
+	cmd, err = parseCommand("update", []string{"-data", "-", "-many", "users"}, strings.NewReader(`{"a": 1}`), io.Discard)
+	assert.NoError(t, err)
+	assert.True(t, cmd.many)
+	assert.Equal(t, map[string]interface{}{"a": int32(1)}, cmd.data[0])
+}
+
+func TestParseCommandFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	_, err := parseCommand("drop", []string{"users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	_, err = parseCommand("find", nil, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	_, err = parseCommand("find", []string{"-filter", "{", "users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	_, err = parseCommand("insert", []string{"users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	_, err = parseCommand("update", []string{"-data", `[{"a": 1}, {"a": 2}]`, "users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	assert.Equal(t, dataSingleMessage, inputError.Message)
+	_, err = parseCommand("insert", []string{"-data", "[]", "users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	assert.Equal(t, fmt.Sprintf(dataEmptyMessage, "insert"), inputError.Message)
+	_, err = parseCommand("find", []string{"-filter", `[{"a": 1}, {"a": 2}]`, "users"}, nil, io.Discard)
+	assert.ErrorAs(t, err, &inputError)
+	assert.Equal(t, filterSingleMessage, inputError.Message)
+}
+
+func TestExecuteSuccess(t *testing.T) {
+	mock := &database.DatabaseInterfaceMock{
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			return nil, &libraryErrors.NotExistError{}
+		},
+		DeleteManyFunc: func(table string, timeout int64, filter map[string]interface{}) (int, error) {
+			return 3, nil
+		},
+		UpdateOneFunc: func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+			return newData.(map[string]interface{}), nil
+		},
+	}
+
+	documents, err := (&command{name: "count", table: "users"}).execute(mock, timeoutTest)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{countField: 0}}, documents)
+
+	documents, err = (&command{name: "delete", table: "users", many: true}).execute(mock, timeoutTest)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{deletedField: 3}}, documents)
+
+	data := []map[string]interface{}{{"a": 1}}
+	documents, err = (&command{name: "update", table: "users", data: data}).execute(mock, timeoutTest)
+	assert.NoError(t, err)
+	assert.Equal(t, data, documents)
+}
@@ -0,0 +1,38 @@
+package main
+
+const (
+	defaultEnvPrefix = "DBCLIENT"
+	formatJSON       = "json"
+	formatNDJSON     = "ndjson"
+	formatTable      = "table"
+	countField       = "count"
+	deletedField     = "deleted"
+	stdinValue       = "-"
+
+	usageMessage = `Usage: dbclient [flags] <command> [command flags] <table>
+
+Commands:
+  find    Finds the documents that match the filter (-filter, -one)
+  insert  Inserts a document or an array of documents (-data)
+  update  Updates the documents that match the filter (-filter, -data, -many)
+  delete  Deletes the documents that match the filter (-filter, -many)
+  count   Counts the documents that match the filter (-filter). DatabaseInterface has no count operation, so
+          all the documents are read: it is slow and memory-hungry on big tables
+
+The filters and the documents are Extended JSON. With -data -, the documents are read from the standard input.
+The connection is read from the -config file, the -dsn and the <PREFIX>_* environment variables, in this order.
+
+Flags:
+`
+	commandMissingMessage = "The command is not defined"
+	unknownCommandMessage = "Unknown command: %s"
+	tableMissingMessage   = "The table of the command %s is not defined"
+	formatMessage         = "Invalid output format: %s. It must be json, ndjson or table"
+	filterMessage         = "Invalid filter: %v"
+	filterSingleMessage   = "Invalid filter: it must be a single document"
+	dataMessage           = "Invalid data: %v"
+	dataMissingMessage    = "The data of the command %s is not defined"
+	dataEmptyMessage      = "The data of the command %s has no documents"
+	dataSingleMessage     = "Invalid data: the command update needs a single document"
+	errorMessage          = "dbclient: %v\n"
+)
@@ -0,0 +1,86 @@
+// Command dbclient runs ad-hoc operations (find, insert, update, delete and count) against any DB supported by
+// the library, reading the connection from the same sources as the config package
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cristianat98/dbclientgo/config"
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run parses the arguments, runs the command and writes its result
+// args: They are the arguments without the name of the binary
+// stdin: It is the input of the documents when -data is -
+// stdout: It is the output of the results
+// stderr: It is the output of the errors and the usage
+// It returns the exit code: 0 on success, 1 when the command fails and 2 when the arguments are not valid
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("dbclient", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usageMessage)
+		flags.PrintDefaults()
+	}
+	file := flags.String("config", "", "YAML or JSON file with the configuration of the DB")
+	dsn := flags.String("dsn", "", "DSN of the DB, like mongodb://localhost:27017/dbName?timeout=5")
+	envPrefix := flags.String("env", defaultEnvPrefix, "Prefix of the environment variables of the configuration")
+	format := flags.String("output", formatJSON, "Output format: json, ndjson or table")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	writer, err := createWriter(*format, stdout)
+	if err != nil {
+		return usageError(stderr, err)
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return usageError(stderr, &libraryErrors.InputError{Message: commandMissingMessage})
+	}
+	cmd, err := parseCommand(flags.Arg(0), flags.Args()[1:], stdin, stderr)
+	if err != nil {
+		return usageError(stderr, err)
+	}
+
+	settings, err := config.Load(config.Sources{File: *file, DSN: *dsn, EnvPrefix: *envPrefix})
+	if err != nil {
+		return commandError(stderr, err)
+	}
+	db, err := config.Open(settings)
+	if err != nil {
+		return commandError(stderr, err)
+	}
+	defer func(db database.DatabaseInterface) {
+		_ = db.DisconnectDb()
+	}(db)
+
+	documents, err := cmd.execute(db, settings.Timeout)
+	if err != nil {
+		return commandError(stderr, err)
+	}
+	if err := writer.write(documents); err != nil {
+		return commandError(stderr, err)
+	}
+	return 0
+}
+
+// usageError writes an error of the arguments and returns its exit code
+func usageError(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, errorMessage, err)
+	return 2
+}
+
+// commandError writes an error of the command and returns its exit code
+func commandError(stderr io.Writer, err error) int {
+	fmt.Fprintf(stderr, errorMessage, err)
+	return 1
+}
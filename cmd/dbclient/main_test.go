@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const dsnTest = "dbclienttest://localhost/test"
+
+// lastTable is the table of the last operation received by the mock of the registered backend
+var lastTable string
+
+func init() {
+	database.Register("dbclienttest", func(ctx context.Context, uri string) (database.DatabaseInterface, error) {
+		return &database.DatabaseInterfaceMock{
+			DisconnectDbFunc: func() error {
+				return nil
+			},
+			FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+				lastTable = table
+				if filter["missing"] != nil {
+					return nil, &libraryErrors.ConnectionError{Db: "test"}
+				}
+				return []map[string]interface{}{{"_id": "1", "name": "a"}, {"_id": "2", "name": "b"}}, nil
+			},
+			InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+				lastTable = table
+				return data, nil
+			},
+		}, nil
+	})
+}
+
+func runTest(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunSuccessFind(t *testing.T) {
+	code, stdout, _ := runTest("", "-dsn", dsnTest, "-output", "ndjson", "find", "users")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "users", lastTable)
+	assert.Equal(t, "{\"_id\":\"1\",\"name\":\"a\"}\n{\"_id\":\"2\",\"name\":\"b\"}\n", stdout)
+
+	code, stdout, _ = runTest("", "-dsn", dsnTest, "count", "users")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "[\n  {\"count\":2}\n]\n", stdout)
+}
+
+func TestRunSuccessInsertFromStdin(t *testing.T) {
+	code, stdout, _ := runTest(`{"name": "c"}`, "-dsn", dsnTest, "-output", "table", "insert", "-data", "-", "users")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "NAME\nc\n", stdout)
+}
+
+func TestRunFailed(t *testing.T) {
+	code, _, stderr := runTest("", "-dsn", dsnTest)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, commandMissingMessage)
+
+	code, _, _ = runTest("", "-dsn", dsnTest, "-output", "xml", "find", "users")
+	assert.Equal(t, 2, code)
+
+	code, _, _ = runTest("", "-dsn", dsnTest, "drop", "users")
+	assert.Equal(t, 2, code)
+
+	code, _, stderr = runTest("", "-dsn", dsnTest, "find", "-filter", `{"missing": true}`, "users")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Connection refused")
+
+	code, _, _ = runTest("", "-env", "DBCLIENT_TEST_UNDEFINED", "find", "users")
+	assert.Equal(t, 1, code)
+}
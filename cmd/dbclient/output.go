@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/internal/bsonutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// writer writes the documents returned by a command in one of the output formats
+// format: It is the output format (json, ndjson or table)
+// output: It is the destination of the documents
+type writer struct {
+	format string
+	output io.Writer
+}
+
+// createWriter is the constructor for the writer
+// format: It is the output format. It must be json, ndjson or table
+// output: It is the destination of the documents
+// It returns the writer and an InputError if the format is not valid
+func createWriter(format string, output io.Writer) (*writer, error) {
+	switch format {
+	case formatJSON, formatNDJSON, formatTable:
+		return &writer{format: format, output: output}, nil
+	default:
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(formatMessage, format)}
+	}
+}
+
+// write writes the documents in the format of the writer
+func (w *writer) write(documents []map[string]interface{}) error {
+	switch w.format {
+	case formatNDJSON:
+		for _, document := range documents {
+			line, err := bson.MarshalExtJSON(bsonutil.OrderDocument(document), false, false)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w.output, "%s\n", line); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatTable:
+		return w.writeTable(documents)
+	default:
+		lines := make([]string, len(documents))
+		for i, document := range documents {
+			line, err := bson.MarshalExtJSON(bsonutil.OrderDocument(document), false, false)
+			if err != nil {
+				return err
+			}
+			lines[i] = "  " + string(line)
+		}
+		if len(lines) == 0 {
+			_, err := fmt.Fprintln(w.output, "[]")
+			return err
+		}
+		_, err := fmt.Fprintf(w.output, "[\n%s\n]\n", strings.Join(lines, ",\n"))
+		return err
+	}
+}
+
+// writeTable writes the documents as a table, with a column per field of the first level
+func (w *writer) writeTable(documents []map[string]interface{}) error {
+	columns := bsonutil.SortedFields(documents...)
+
+	table := tabwriter.NewWriter(w.output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, document := range documents {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			if value, found := document[column]; found {
+				text, err := bsonutil.FormatValue(value)
+				if err != nil {
+					text = fmt.Sprint(value)
+				}
+				cells[i] = text
+			}
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	return table.Flush()
+}
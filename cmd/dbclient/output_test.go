@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWriteSuccess(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex("5f1d7f3e9d3b2a1c4e8b4567")
+	date := primitive.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	documents := []map[string]interface{}{
+		{"name": "a", "_id": id, "created": date},
+		{"_id": id, "tags": []interface{}{"x"}},
+	}
+
+	var output bytes.Buffer
+	w, err := createWriter(formatJSON, &output)
+	assert.NoError(t, err)
+	assert.NoError(t, w.write(documents[:1]))
+	assert.Equal(t, "[\n  {\"_id\":{\"$oid\":\"5f1d7f3e9d3b2a1c4e8b4567\"},\"created\":{\"$date\":\"2024-01-02T03:04:05Z\"},\"name\":\"a\"}\n]\n", output.String())
+
+	output.Reset()
+	assert.NoError(t, w.write(nil))
+	assert.Equal(t, "[]\n", output.String())
+
+	output.Reset()
+	w, _ = createWriter(formatTable, &output)
+	assert.NoError(t, w.write(documents))
+	assert.Equal(t, "_ID                       CREATED               NAME  TAGS\n"+
+		"5f1d7f3e9d3b2a1c4e8b4567  2024-01-02T03:04:05Z  a     \n"+
+		"5f1d7f3e9d3b2a1c4e8b4567                              [\"x\"]\n", output.String())
+}
+
+func TestCreateWriterFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	_, err := createWriter("xml", &bytes.Buffer{})
+	assert.ErrorAs(t, err, &inputError)
+}
@@ -0,0 +1,14 @@
+package config
+
+const (
+	defaultTimeout      = 10
+	mongoScheme         = "mongodb"
+	uriMissingMessage   = "The URI of the DB is not defined"
+	timeoutMessage      = "Invalid timeout: %d. It must be higher than 0"
+	secretFileMessage   = "Secret file %s can not be read: %v"
+	invalidValueMessage = "Invalid value for %s: %s"
+	dsnMessage          = "Invalid DSN: %s"
+	uriMessage          = "Invalid URI: %s"
+	fileMessage         = "Configuration file %s can not be read: %v"
+	extensionMessage    = "Invalid configuration file %s. It must be YAML or JSON"
+)
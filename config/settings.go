@@ -0,0 +1,284 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/mongo"
+)
+
+// Settings is the structure with the configuration of a Manager. The fields have the same meaning as the
+// Config of each Manager. The empty fields are considered not defined
+// URI: It is the URI to connect to the DB
+// URIFile: It is a file that contains the URI, to keep it out of the configuration. It replaces URI when both are
+// defined in the same source, and it follows the precedence of the sources otherwise
+// Database: It is the name of the DB
+// Timeout: It is the timeout of the connection in seconds
+// PasswordFile: It is a file that contains the password. It replaces Password when it is defined and it is also
+// used with the username of the URI
+type Settings struct {
+	URI                   string   `json:"uri" yaml:"uri" env:"URI"`
+	URIFile               string   `json:"uri_file" yaml:"uri_file" env:"URI_FILE"`
+	Database              string   `json:"database" yaml:"database" env:"DATABASE"`
+	Timeout               int64    `json:"timeout" yaml:"timeout" env:"TIMEOUT"`
+	AppName               string   `json:"app_name" yaml:"app_name" env:"APP_NAME"`
+	MinPoolSize           uint64   `json:"min_pool_size" yaml:"min_pool_size" env:"MIN_POOL_SIZE"`
+	MaxPoolSize           uint64   `json:"max_pool_size" yaml:"max_pool_size" env:"MAX_POOL_SIZE"`
+	ReadPreference        string   `json:"read_preference" yaml:"read_preference" env:"READ_PREFERENCE"`
+	WriteConcern          string   `json:"write_concern" yaml:"write_concern" env:"WRITE_CONCERN"`
+	Compressors           []string `json:"compressors" yaml:"compressors" env:"COMPRESSORS"`
+	TLSCAFile             string   `json:"tls_ca_file" yaml:"tls_ca_file" env:"TLS_CA_FILE"`
+	TLSCertificateKeyFile string   `json:"tls_certificate_key_file" yaml:"tls_certificate_key_file" env:"TLS_CERTIFICATE_KEY_FILE"`
+	AuthMechanism         string   `json:"auth_mechanism" yaml:"auth_mechanism" env:"AUTH_MECHANISM"`
+	AuthSource            string   `json:"auth_source" yaml:"auth_source" env:"AUTH_SOURCE"`
+	Username              string   `json:"username" yaml:"username" env:"USERNAME"`
+	Password              string   `json:"password" yaml:"password" env:"PASSWORD"`
+	PasswordFile          string   `json:"password_file" yaml:"password_file" env:"PASSWORD_FILE"`
+}
+
+// Scheme returns the scheme of the URI (mongodb, mongodb+srv...)
+func (settings Settings) Scheme() string {
+	scheme, _, found := strings.Cut(settings.URI, "://")
+	if !found {
+		return ""
+	}
+	return strings.ToLower(scheme)
+}
+
+// MongoOptions converts the Settings to the options of the mongo Manager
+func (settings Settings) MongoOptions() []mongo.Option {
+	return []mongo.Option{func(config *mongo.Config) {
+		config.AppName = settings.AppName
+		config.MinPoolSize = settings.MinPoolSize
+		config.MaxPoolSize = settings.MaxPoolSize
+		config.ReadPreference = settings.ReadPreference
+		config.WriteConcern = settings.WriteConcern
+		config.Compressors = settings.Compressors
+		config.TLSCAFile = settings.TLSCAFile
+		config.TLSCertificateKeyFile = settings.TLSCertificateKeyFile
+		config.AuthMechanism = settings.AuthMechanism
+		config.AuthSource = settings.AuthSource
+		config.Username = settings.Username
+		config.Password = settings.Password
+	}}
+}
+
+// connectionURI returns the URI with the Settings that are not part of it. The name of the DB is the path, the
+// credentials are the user information and the rest of the Settings are the options of the MongoDB connection
+// strings (appName, maxPoolSize, w...). The Settings replace the values already defined in the URI
+func (settings Settings) connectionURI() (string, error) {
+	scheme, rest, found := strings.Cut(settings.URI, "://")
+	if !found || scheme == "" || rest == "" {
+		return "", &libraryErrors.InputError{Message: fmt.Sprintf(uriMessage, settings.URI)}
+	}
+	rest, rawQuery, _ := strings.Cut(rest, "?")
+	authority, path, _ := strings.Cut(rest, "/")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil || authority == "" {
+		return "", &libraryErrors.InputError{Message: fmt.Sprintf(uriMessage, settings.URI)}
+	}
+
+	if settings.Username != "" || settings.Password != "" {
+		userInfo, hosts := "", authority
+		if at := strings.LastIndex(authority, "@"); at >= 0 {
+			userInfo, hosts = authority[:at], authority[at+1:]
+		}
+		user, err := settings.userInfo(userInfo)
+		if err != nil {
+			return "", err
+		}
+		authority = user.String() + "@" + hosts
+	}
+	if settings.Database != "" {
+		path = settings.Database
+	}
+	for parameter, value := range map[string]string{
+		"appName":               settings.AppName,
+		"readPreference":        settings.ReadPreference,
+		"w":                     settings.WriteConcern,
+		"compressors":           strings.Join(settings.Compressors, ","),
+		"tlsCAFile":             settings.TLSCAFile,
+		"tlsCertificateKeyFile": settings.TLSCertificateKeyFile,
+		"authMechanism":         settings.AuthMechanism,
+		"authSource":            settings.AuthSource,
+	} {
+		if value != "" {
+			query.Set(parameter, value)
+		}
+	}
+	if settings.MinPoolSize != 0 {
+		query.Set("minPoolSize", strconv.FormatUint(settings.MinPoolSize, 10))
+	}
+	if settings.MaxPoolSize != 0 {
+		query.Set("maxPoolSize", strconv.FormatUint(settings.MaxPoolSize, 10))
+	}
+	if settings.TLSCAFile != "" || settings.TLSCertificateKeyFile != "" {
+		query.Set("tls", "true")
+	}
+
+	uri := scheme + "://" + authority + "/" + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	return uri, nil
+}
+
+// userInfo replaces the username and the password of the user information of the URI with the ones of the Settings
+func (settings Settings) userInfo(userInfo string) (*url.Userinfo, error) {
+	rawUsername, rawPassword, hasPassword := strings.Cut(userInfo, ":")
+	username, err := url.PathUnescape(rawUsername)
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(uriMessage, settings.URI)}
+	}
+	password, err := url.PathUnescape(rawPassword)
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(uriMessage, settings.URI)}
+	}
+	if settings.Username != "" {
+		username = settings.Username
+	}
+	if settings.Password != "" {
+		password, hasPassword = settings.Password, true
+	}
+	if !hasPassword {
+		return url.User(username), nil
+	}
+	return url.UserPassword(username, password), nil
+}
+
+// merge overwrites the fields of the Settings with the fields defined in other. A secret defined in other replaces
+// the file of the secret of the previous sources and the other way round, so the precedence of the sources is kept
+func (settings *Settings) merge(other Settings) {
+	target := reflect.ValueOf(settings).Elem()
+	source := reflect.ValueOf(other)
+	for i := 0; i < source.NumField(); i++ {
+		if !source.Field(i).IsZero() {
+			target.Field(i).Set(source.Field(i))
+		}
+	}
+	if other.URI != "" && other.URIFile == "" {
+		settings.URIFile = ""
+	}
+	if other.URIFile != "" && other.URI == "" {
+		settings.URI = ""
+	}
+	if other.Password != "" && other.PasswordFile == "" {
+		settings.PasswordFile = ""
+	}
+	if other.PasswordFile != "" && other.Password == "" {
+		settings.Password = ""
+	}
+}
+
+// resolveSecrets reads the files referenced by URIFile and PasswordFile
+func (settings *Settings) resolveSecrets() error {
+	if settings.URIFile != "" {
+		content, err := readSecret(settings.URIFile)
+		if err != nil {
+			return err
+		}
+		settings.URI = content
+	}
+	if settings.PasswordFile != "" {
+		content, err := readSecret(settings.PasswordFile)
+		if err != nil {
+			return err
+		}
+		settings.Password = content
+	}
+	return nil
+}
+
+// validate checks that the required fields are defined
+func (settings Settings) validate() error {
+	if settings.URI == "" {
+		return &libraryErrors.InputError{Message: uriMissingMessage}
+	}
+	if settings.Timeout < 1 {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, settings.Timeout)}
+	}
+	return nil
+}
+
+// readSecret reads a file with a secret, removing the spaces and new lines at the end
+func readSecret(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", &libraryErrors.InputError{Message: fmt.Sprintf(secretFileMessage, path, err)}
+	}
+	return strings.TrimRight(string(content), " \r\n"), nil
+}
+
+// setField assigns the text value to a field of the Settings, converting it to the type of the field
+func setField(field reflect.Value, name, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int64:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(invalidValueMessage, name, value)}
+		}
+		field.SetInt(number)
+	case reflect.Uint64:
+		number, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(invalidValueMessage, name, value)}
+		}
+		field.SetUint(number)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	}
+	return nil
+}
+
+// dsnParameters maps the parameters of a DSN that are not part of the URI of the DB to the fields of the Settings
+var dsnParameters = map[string]string{
+	"timeout":       "Timeout",
+	"password_file": "PasswordFile",
+}
+
+// parseDSN parses the DSN, extracting the name of the DB from the path and the parameters of dsnParameters.
+// It is parsed by hand because url.Parse does not accept the lists of hosts of the MongoDB URIs
+func parseDSN(dsn string) (Settings, error) {
+	var settings Settings
+	scheme, rest, found := strings.Cut(dsn, "://")
+	if !found || scheme == "" || rest == "" {
+		return settings, &libraryErrors.InputError{Message: fmt.Sprintf(dsnMessage, dsn)}
+	}
+	rest, rawQuery, _ := strings.Cut(rest, "?")
+	authority, path, _ := strings.Cut(rest, "/")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil || authority == "" {
+		return settings, &libraryErrors.InputError{Message: fmt.Sprintf(dsnMessage, dsn)}
+	}
+
+	target := reflect.ValueOf(&settings).Elem()
+	for parameter, fieldName := range dsnParameters {
+		if !query.Has(parameter) {
+			continue
+		}
+		if err := setField(target.FieldByName(fieldName), parameter, query.Get(parameter)); err != nil {
+			return settings, err
+		}
+		query.Del(parameter)
+	}
+
+	settings.Database = path
+	settings.URI = scheme + "://" + authority + "/" + path
+	if len(query) > 0 {
+		settings.URI += "?" + query.Encode()
+	}
+	return settings, nil
+}
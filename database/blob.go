@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobInfo is the description of a binary object of a BlobStore
+// ID: It is the identifier of the object, returned by Upload
+// Name: It is the name of the object. Many objects can have the same name
+// Size: It is the size of the object in bytes
+// UploadDate: It is the moment when the object was uploaded
+// Metadata: It is the extra data saved with the object
+type BlobInfo struct {
+	ID         string
+	Name       string
+	Size       int64
+	UploadDate time.Time
+	Metadata   map[string]interface{}
+}
+
+// BlobStore is implemented by the Managers that can store binary objects bigger than the documents of their tables
+type BlobStore interface {
+	// Upload saves the content of source as a new object
+	Upload(ctx context.Context, name string, source io.Reader, metadata map[string]interface{}) (BlobInfo, error)
+	// Download writes the content of the object to destination and returns the number of bytes written
+	Download(ctx context.Context, id string, destination io.Writer) (int64, error)
+	// Delete removes the object
+	Delete(ctx context.Context, id string) error
+	// List returns the objects that match the filter
+	List(ctx context.Context, filter map[string]interface{}) ([]BlobInfo, error)
+	// OpenRange returns a reader of length bytes of the object from offset. If length is negative, it reads until
+	// the end. The reader must be closed
+	OpenRange(ctx context.Context, id string, offset, length int64) (io.ReadCloser, error)
+}
@@ -0,0 +1,63 @@
+package database
+
+// ReadPreference defines which nodes of the DB can answer the reads
+type ReadPreference string
+
+// ReadConcern defines the isolation level of the data returned by the reads
+type ReadConcern string
+
+// WriteConcern defines the acknowledgment required for the writes
+type WriteConcern string
+
+const (
+	// ReadPrimary reads only from the primary node
+	ReadPrimary ReadPreference = "primary"
+	// ReadPrimaryPreferred reads from the primary node, or from a replica if it is not available
+	ReadPrimaryPreferred ReadPreference = "primaryPreferred"
+	// ReadSecondary reads only from the replicas
+	ReadSecondary ReadPreference = "secondary"
+	// ReadSecondaryPreferred reads from the replicas, or from the primary node if there are no replicas available
+	ReadSecondaryPreferred ReadPreference = "secondaryPreferred"
+	// ReadNearest reads from the node with the lowest latency
+	ReadNearest ReadPreference = "nearest"
+)
+
+const (
+	// ReadConcernLocal returns the most recent data of the node, which may be rolled back
+	ReadConcernLocal ReadConcern = "local"
+	// ReadConcernAvailable returns the data available in the node with the lowest latency, which may be rolled back
+	ReadConcernAvailable ReadConcern = "available"
+	// ReadConcernMajority returns only the data acknowledged by the majority of the nodes
+	ReadConcernMajority ReadConcern = "majority"
+	// ReadConcernLinearizable returns the data acknowledged by the majority before the read started
+	ReadConcernLinearizable ReadConcern = "linearizable"
+	// ReadConcernSnapshot returns the data of a snapshot acknowledged by the majority
+	ReadConcernSnapshot ReadConcern = "snapshot"
+)
+
+const (
+	// WriteUnacknowledged does not wait for any acknowledgment. The writes can not be read back, so the inserts
+	// return the documents sent with their _id, the updates return no documents and the deletes return 0
+	WriteUnacknowledged WriteConcern = "unacknowledged"
+	// WriteAcknowledged waits for the acknowledgment of the primary node
+	WriteAcknowledged WriteConcern = "acknowledged"
+	// WriteMajority waits for the acknowledgment of the majority of the nodes
+	WriteMajority WriteConcern = "majority"
+	// WriteMajorityJournaled waits for the majority of the nodes to write the data in the journal
+	WriteMajorityJournaled WriteConcern = "majorityJournaled"
+)
+
+// Consistency is the structure with the consistency of the operations. The empty fields keep the default values
+// of the Manager
+type Consistency struct {
+	ReadPreference ReadPreference
+	ReadConcern    ReadConcern
+	WriteConcern   WriteConcern
+}
+
+// ConsistencyConfigurable is the interface of the Managers that allow defining the Consistency per call
+type ConsistencyConfigurable interface {
+	// WithConsistency returns a DatabaseInterface that shares the connection of the Manager and applies the
+	// Consistency to its operations. It returns an InputError if the Consistency is not supported
+	WithConsistency(consistency Consistency) (DatabaseInterface, error)
+}
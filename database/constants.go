@@ -0,0 +1,6 @@
+package database
+
+const (
+	invalidURIMessage    = "Invalid URI: %s"
+	unknownSchemeMessage = "Scheme %q is not registered. Check that the package of the Manager is imported"
+)
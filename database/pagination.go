@@ -0,0 +1,32 @@
+package database
+
+// PageRequest is the structure to ask for a page of documents sorted by a key
+// SortKey: It is the field that sorts the documents. The _id is added to break the ties. If it is empty, the
+// documents are sorted by _id
+// Descending: It sorts the documents from the highest to the lowest value
+// Size: It is the maximum number of documents of the page
+// Token: It is the Next or Previous token of a Page. If it is empty, the first page is returned
+type PageRequest struct {
+	SortKey    string
+	Descending bool
+	Size       int64
+	Token      string
+}
+
+// Page is a page of documents with the tokens to navigate to the pages around it
+// Documents: They are the documents of the page, in the order of the PageRequest
+// Next: It is the token of the following page. It is empty if there are no more documents
+// Previous: It is the token of the page before. It is empty in the first page
+type Page struct {
+	Documents []map[string]interface{}
+	Next      string
+	Previous  string
+}
+
+// Paginator is implemented by the Managers that can paginate the results of a filter by the keys of the
+// documents (keyset pagination), which does not slow down in the last pages like the offsets do
+type Paginator interface {
+	// Paginate returns the page of the documents of the table that match the filter. The tokens are opaque and
+	// signed, and they are only valid for the same table and sort
+	Paginate(table string, timeout int64, filter map[string]interface{}, request PageRequest) (Page, error)
+}
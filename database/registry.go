@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Factory is a function that creates a DatabaseInterface connected to the URI
+type Factory func(ctx context.Context, uri string) (DatabaseInterface, error)
+
+var (
+	factoriesMutex sync.RWMutex
+	factories      = make(map[string]Factory)
+)
+
+// Register makes a Factory available for the URIs with the scheme. It is usually called from the init function
+// of the package of the Manager, like the drivers of database/sql. It panics if the scheme is registered twice
+// or if the factory is nil
+// scheme: It is the scheme of the URIs (mongodb, mongodb+srv...)
+// factory: It is the function that creates the DatabaseInterface
+func Register(scheme string, factory Factory) {
+	factoriesMutex.Lock()
+	defer factoriesMutex.Unlock()
+	scheme = strings.ToLower(scheme)
+	if factory == nil {
+		panic("database: Register factory is nil for scheme " + scheme)
+	}
+	if _, found := factories[scheme]; found {
+		panic("database: Register called twice for scheme " + scheme)
+	}
+	factories[scheme] = factory
+}
+
+// Schemes returns the sorted list of the schemes registered
+func Schemes() []string {
+	factoriesMutex.RLock()
+	defer factoriesMutex.RUnlock()
+	schemes := make([]string, 0, len(factories))
+	for scheme := range factories {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// Open creates the DatabaseInterface registered for the scheme of the URI and connects it
+// ctx: It is the context of the connection. Its deadline is used as the timeout of the connection
+// uri: It is the URI to connect to the DB. Each Manager defines how the name of the DB is included in it
+// It returns the DatabaseInterface and an error
+func Open(ctx context.Context, uri string) (DatabaseInterface, error) {
+	scheme, _, found := strings.Cut(uri, "://")
+	if !found || scheme == "" {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(invalidURIMessage, uri)}
+	}
+
+	factoriesMutex.RLock()
+	factory, found := factories[strings.ToLower(scheme)]
+	factoriesMutex.RUnlock()
+	if !found {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(unknownSchemeMessage, scheme)}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return factory(ctx, uri)
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const schemeTest = "registrytest"
+
+func TestOpenSuccess(t *testing.T) {
+	mock := new(DatabaseInterfaceMock)
+	var uriReceived string
+	Register(schemeTest, func(ctx context.Context, uri string) (DatabaseInterface, error) {
+		uriReceived = uri
+		return mock, nil
+	})
+
+	db, err := Open(context.Background(), "RegistryTest://host/test")
+	assert.NoError(t, err)
+	assert.Same(t, mock, db)
+	assert.Equal(t, "RegistryTest://host/test", uriReceived)
+	assert.Contains(t, Schemes(), schemeTest)
+
+	assert.Panics(t, func() {
+		Register(schemeTest, func(ctx context.Context, uri string) (DatabaseInterface, error) {
+			return nil, nil
+		})
+	})
+	assert.Panics(t, func() {
+		Register("nil"+schemeTest, nil)
+	})
+}
+
+func TestOpenFailedInvalidURI(t *testing.T) {
+	var myErr *libraryErrors.InputError
+
+	db, err := Open(context.Background(), "test")
+	assert.Nil(t, db)
+	assert.ErrorAs(t, err, &myErr)
+
+	db, err = Open(context.Background(), "unknown://host/test")
+	assert.Nil(t, db)
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestOpenFailedContextCancelled(t *testing.T) {
+	Register("cancelled"+schemeTest, func(ctx context.Context, uri string) (DatabaseInterface, error) {
+		return new(DatabaseInterfaceMock), nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db, err := Open(ctx, "cancelled"+schemeTest+"://host/test")
+	assert.Nil(t, db)
+	assert.ErrorIs(t, err, context.Canceled)
+}
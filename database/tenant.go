@@ -0,0 +1,26 @@
+package database
+
+import "context"
+
+// tenantKey is the key of the tenant inside the context
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of the context that carries the tenant. The Managers and decorators that
+// support multi-tenancy read it with TenantFromContext
+// ctx: It is the parent context
+// tenant: It is the identifier of the tenant
+// It returns the new context
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant carried by the context
+// ctx: It is the context with the tenant
+// It returns the tenant and false if the context does not carry a tenant or it is empty
+func TenantFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok && tenant != ""
+}
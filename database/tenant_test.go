@@ -0,0 +1,24 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantFromContextSuccess(t *testing.T) {
+	ctx := ContextWithTenant(context.Background(), "tenant1")
+
+	tenant, ok := TenantFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "tenant1", tenant)
+}
+
+func TestTenantFromContextFailedMissing(t *testing.T) {
+	_, ok := TenantFromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = TenantFromContext(ContextWithTenant(context.Background(), ""))
+	assert.False(t, ok)
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeType is the kind of change of a ChangeEvent
+type ChangeType string
+
+// Kinds of changes delivered by a ChangeStream
+const (
+	ChangeInsert  ChangeType = "insert"
+	ChangeUpdate  ChangeType = "update"
+	ChangeReplace ChangeType = "replace"
+	ChangeDelete  ChangeType = "delete"
+)
+
+// ChangeEvent is a change of a document of a table
+// Type: It is the kind of change
+// Table: It is the table of the document
+// DocumentKey: It is the key of the document (for example, its _id)
+// FullDocument: It is the document after the change. It is nil for the deletions
+// UpdatedFields: They are the fields changed by an update
+// RemovedFields: They are the fields removed by an update
+// ResumeToken: It is the opaque position of the event in the stream. It is used to resume after it
+// Time: It is the moment of the change in the DB
+type ChangeEvent struct {
+	Type          ChangeType
+	Table         string
+	DocumentKey   map[string]interface{}
+	FullDocument  map[string]interface{}
+	UpdatedFields map[string]interface{}
+	RemovedFields []string
+	ResumeToken   []byte
+	Time          time.Time
+}
+
+// ChangeStream is a stream of ChangeEvent of a table
+type ChangeStream interface {
+	// Next blocks until there is a new event, ctx is done or the stream fails
+	Next(ctx context.Context) (ChangeEvent, error)
+	// ResumeToken returns the token of the last event returned by Next, or the token used to open the stream
+	ResumeToken() []byte
+	// Close stops the stream
+	Close() error
+}
+
+// Watcher is implemented by the Managers that can notify the changes of a table, instead of polling it
+type Watcher interface {
+	// Watch opens a stream with the changes of the table that happen from now on. The filter applies to the fields
+	// of the documents, and the deletions are always delivered because they do not contain the document
+	Watch(ctx context.Context, table string, filter map[string]interface{}) (ChangeStream, error)
+	// WatchFrom opens a stream with the changes of the table after the resume token of a previous event
+	WatchFrom(ctx context.Context, table string, filter map[string]interface{}, resumeToken []byte) (ChangeStream, error)
+}
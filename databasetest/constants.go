@@ -0,0 +1,11 @@
+package databasetest
+
+const (
+	// Table is the table used by the suite. It is emptied with DeleteMany before each test
+	Table       = "databasetest"
+	timeoutTest = 5
+	idField     = "_id"
+
+	factoryMessage  = "The factory returned a nil DatabaseInterface"
+	notEmptyMessage = "The table %s is not empty after DeleteMany"
+)
@@ -0,0 +1,330 @@
+// Package databasetest contains the conformance suite that every implementation of the DatabaseInterface must
+// pass, so the code that uses the interface behaves the same with any Manager
+package databasetest
+
+import (
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// RunSuite runs the conformance tests of the DatabaseInterface as subtests of t. Each subtest creates a
+// DatabaseInterface with factory, empties Table and disconnects it at the end
+// t: It is the test that runs the suite
+// factory: It returns a DatabaseInterface connected to the DB of the tests, or nil if it can not be created
+func RunSuite(t *testing.T, factory func() database.DatabaseInterface) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, db database.DatabaseInterface)
+	}{
+		{"InsertOneSuccess", testInsertOneSuccess},
+		{"InsertOneFailedIdAlreadyExists", testInsertOneFailedIdAlreadyExists},
+		{"InsertManySuccess", testInsertManySuccess},
+		{"InsertManyFailedIdAlreadyExists", testInsertManyFailedIdAlreadyExists},
+		{"FindOneSuccess", testFindOneSuccess},
+		{"FindOneFailedNoExist", testFindOneFailedNoExist},
+		{"FindManySuccess", testFindManySuccess},
+		{"FindManySuccessEmpty", testFindManySuccessEmpty},
+		{"UpdateOneSuccess", testUpdateOneSuccess},
+		{"UpdateOneAddFieldSuccess", testUpdateOneAddFieldSuccess},
+		{"UpdateOneFailedNoExist", testUpdateOneFailedNoExist},
+		{"UpdateManySuccess", testUpdateManySuccess},
+		{"UpdateManyFailedNoExist", testUpdateManyFailedNoExist},
+		{"DeleteOneSuccess", testDeleteOneSuccess},
+		{"DeleteOneFailedNoExist", testDeleteOneFailedNoExist},
+		{"DeleteManySuccess", testDeleteManySuccess},
+		{"DeleteManySuccessEmpty", testDeleteManySuccessEmpty},
+		{"FailedInvalidTimeout", testFailedInvalidTimeout},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := prepare(t, factory)
+			t.Cleanup(func() {
+				assert.NoError(t, db.DisconnectDb())
+			})
+			test.run(t, db)
+		})
+	}
+	t.Run("FailedDisconnected", func(t *testing.T) {
+		testFailedDisconnected(t, prepare(t, factory))
+	})
+}
+
+// prepare creates the DatabaseInterface and empties Table
+func prepare(t *testing.T, factory func() database.DatabaseInterface) database.DatabaseInterface {
+	db := factory()
+	if db == nil {
+		t.Fatal(factoryMessage)
+	}
+	_, err := db.DeleteMany(Table, timeoutTest, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := db.FindMany(Table, timeoutTest, map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 0 {
+		t.Fatalf(notEmptyMessage, Table)
+	}
+	return db
+}
+
+// withID returns a copy of the document with the _id of the result
+func withID(document, result map[string]interface{}) map[string]interface{} {
+	expected := make(map[string]interface{}, len(document)+1)
+	for key, value := range document {
+		expected[key] = value
+	}
+	expected[idField] = result[idField]
+	return expected
+}
+
+func testInsertOneSuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"test": "test"}
+	result, err := db.InsertOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.NotNil(t, result[idField])
+	assert.Equal(t, withID(document, result), result)
+}
+
+func testInsertOneFailedIdAlreadyExists(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.InsertOne(Table, timeoutTest, map[string]interface{}{"test": "test"})
+	assert.NoError(t, err)
+
+	result, err = db.InsertOne(Table, timeoutTest, map[string]interface{}{"test": "test", idField: result[idField]})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.AlreadyExistError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func testInsertManySuccess(t *testing.T, db database.DatabaseInterface) {
+	documents := []map[string]interface{}{{"document1": "test"}, {"document2": "test"}}
+	result, err := db.InsertMany(Table, timeoutTest, documents)
+	assert.NoError(t, err)
+	assert.Len(t, result, len(documents))
+	for index, item := range result {
+		assert.NotNil(t, item[idField])
+		assert.Equal(t, withID(documents[index], item), item)
+	}
+}
+
+// testInsertManyFailedIdAlreadyExists checks that the documents are inserted in order until the duplicated one,
+// and that the inserted ones are returned with the error
+func testInsertManyFailedIdAlreadyExists(t *testing.T, db database.DatabaseInterface) {
+	existing, err := db.InsertOne(Table, timeoutTest, map[string]interface{}{"document2": "test"})
+	assert.NoError(t, err)
+
+	documents := []map[string]interface{}{{"document1": "test"}, {"document2": "test", idField: existing[idField]}, {"document3": "test"}}
+	result, err := db.InsertMany(Table, timeoutTest, documents)
+	var myErr *libraryErrors.AlreadyExistError
+	assert.ErrorAs(t, err, &myErr)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, withID(documents[0], result[0]), result[0])
+	}
+}
+
+func testFindOneSuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"test": "test"}
+	resultInsert, err := db.InsertOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+
+	resultFind, err := db.FindOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.Equal(t, resultInsert, resultFind)
+
+	resultFind, err = db.FindOne(Table, timeoutTest, map[string]interface{}{idField: resultInsert[idField]})
+	assert.NoError(t, err)
+	assert.Equal(t, resultInsert, resultFind)
+}
+
+func testFindOneFailedNoExist(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.FindOne(Table, timeoutTest, map[string]interface{}{"test": "test"})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func testFindManySuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"document": "test"}
+	resultInsert, err := db.InsertMany(Table, timeoutTest, []map[string]interface{}{document, document})
+	assert.NoError(t, err)
+	_, err = db.InsertOne(Table, timeoutTest, map[string]interface{}{"document": "other"})
+	assert.NoError(t, err)
+
+	resultFind, err := db.FindMany(Table, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.Equal(t, resultInsert, resultFind)
+
+	resultFind, err = db.FindMany(Table, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, resultFind, 3)
+}
+
+// testFindManySuccessEmpty checks that no documents is not an error
+func testFindManySuccessEmpty(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.FindMany(Table, timeoutTest, map[string]interface{}{"test": "test"})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func testUpdateOneSuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"test": "test"}
+	resultInsert, err := db.InsertOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+
+	resultUpdate, err := db.UpdateOne(Table, timeoutTest, document, map[string]interface{}{"test": "test2"})
+	assert.NoError(t, err)
+	expected := withID(map[string]interface{}{"test": "test2"}, resultInsert)
+	assert.Equal(t, expected, resultUpdate)
+
+	resultFind, err := db.FindOne(Table, timeoutTest, map[string]interface{}{idField: resultInsert[idField]})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resultFind)
+}
+
+func testUpdateOneAddFieldSuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"test": "test"}
+	resultInsert, err := db.InsertOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+
+	resultUpdate, err := db.UpdateOne(Table, timeoutTest, document, map[string]interface{}{"test2": "test2"})
+	assert.NoError(t, err)
+	assert.Equal(t, withID(map[string]interface{}{"test": "test", "test2": "test2"}, resultInsert), resultUpdate)
+}
+
+func testUpdateOneFailedNoExist(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.UpdateOne(Table, timeoutTest, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test"})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func testUpdateManySuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"document": "test"}
+	resultInsert, err := db.InsertMany(Table, timeoutTest, []map[string]interface{}{document, document})
+	assert.NoError(t, err)
+
+	resultUpdate, err := db.UpdateMany(Table, timeoutTest, document, map[string]interface{}{"document": "test2"})
+	assert.NoError(t, err)
+	if assert.Len(t, resultUpdate, len(resultInsert)) {
+		for index, item := range resultInsert {
+			assert.Equal(t, withID(map[string]interface{}{"document": "test2"}, item), resultUpdate[index])
+		}
+	}
+}
+
+func testUpdateManyFailedNoExist(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.UpdateMany(Table, timeoutTest, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test"})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func testDeleteOneSuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"test": "test"}
+	_, err := db.InsertMany(Table, timeoutTest, []map[string]interface{}{document, document})
+	assert.NoError(t, err)
+
+	err = db.DeleteOne(Table, timeoutTest, document)
+	assert.NoError(t, err)
+
+	result, err := db.FindMany(Table, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+}
+
+func testDeleteOneFailedNoExist(t *testing.T, db database.DatabaseInterface) {
+	err := db.DeleteOne(Table, timeoutTest, map[string]interface{}{"test": "test"})
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func testDeleteManySuccess(t *testing.T, db database.DatabaseInterface) {
+	document := map[string]interface{}{"document": "test"}
+	_, err := db.InsertMany(Table, timeoutTest, []map[string]interface{}{document, document, {"document": "other"}})
+	assert.NoError(t, err)
+
+	result, err := db.DeleteMany(Table, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result)
+
+	remaining, err := db.FindMany(Table, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}
+
+// testDeleteManySuccessEmpty checks that deleting no documents is not an error
+func testDeleteManySuccessEmpty(t *testing.T, db database.DatabaseInterface) {
+	result, err := db.DeleteMany(Table, timeoutTest, map[string]interface{}{"test": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result)
+}
+
+// testFailedInvalidTimeout checks that every operation rejects a timeout lower than 1 with an InputError and
+// returns the zero values
+func testFailedInvalidTimeout(t *testing.T, db database.DatabaseInterface) {
+	filter := map[string]interface{}{"test": "test"}
+	var myErr *libraryErrors.InputError
+
+	resultOne, err := db.InsertOne(Table, 0, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err := db.InsertMany(Table, 0, []map[string]interface{}{filter})
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	resultOne, err = db.FindOne(Table, 0, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err = db.FindMany(Table, 0, filter)
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	resultOne, err = db.UpdateOne(Table, 0, filter, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err = db.UpdateMany(Table, 0, filter, filter)
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	err = db.DeleteOne(Table, 0, filter)
+	assert.ErrorAs(t, err, &myErr)
+	deleted, err := db.DeleteMany(Table, 0, filter)
+	assert.Equal(t, 0, deleted)
+	assert.ErrorAs(t, err, &myErr)
+}
+
+// testFailedDisconnected checks that every operation returns a ClientError after DisconnectDb
+func testFailedDisconnected(t *testing.T, db database.DatabaseInterface) {
+	assert.NoError(t, db.DisconnectDb())
+
+	filter := map[string]interface{}{"test": "test"}
+	var myErr *libraryErrors.ClientError
+
+	resultOne, err := db.InsertOne(Table, timeoutTest, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err := db.InsertMany(Table, timeoutTest, []map[string]interface{}{filter})
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	resultOne, err = db.FindOne(Table, timeoutTest, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err = db.FindMany(Table, timeoutTest, filter)
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	resultOne, err = db.UpdateOne(Table, timeoutTest, filter, filter)
+	assert.Nil(t, resultOne)
+	assert.ErrorAs(t, err, &myErr)
+	resultMany, err = db.UpdateMany(Table, timeoutTest, filter, filter)
+	assert.Nil(t, resultMany)
+	assert.ErrorAs(t, err, &myErr)
+	err = db.DeleteOne(Table, timeoutTest, filter)
+	assert.ErrorAs(t, err, &myErr)
+	deleted, err := db.DeleteMany(Table, timeoutTest, filter)
+	assert.Equal(t, 0, deleted)
+	assert.ErrorAs(t, err, &myErr)
+
+	err = db.DisconnectDb()
+	assert.ErrorAs(t, err, &myErr)
+}
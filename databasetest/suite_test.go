@@ -0,0 +1,195 @@
+package databasetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// memoryDatabase is a DatabaseInterface that keeps the tables in memory, used to check the suite itself. The
+// filters only compare the fields of the first level
+type memoryDatabase struct {
+	mutex     sync.Mutex
+	connected bool
+	nextID    int
+	tables    map[string][]map[string]interface{}
+}
+
+func (db *memoryDatabase) ConnectDb(dbURI, dbName string, timeout int64) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.connected = true
+	return nil
+}
+
+func (db *memoryDatabase) DisconnectDb() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if !db.connected {
+		return &libraryErrors.ClientError{Message: "not connected"}
+	}
+	db.connected = false
+	return nil
+}
+
+func (db *memoryDatabase) InsertOne(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+	results, err := db.InsertMany(table, timeout, []map[string]interface{}{data})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (db *memoryDatabase) InsertMany(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.check(timeout); err != nil {
+		return nil, err
+	}
+	var inserted []map[string]interface{}
+	for _, document := range data {
+		stored := copyDocument(document)
+		if _, found := stored[idField]; !found {
+			db.nextID++
+			stored[idField] = fmt.Sprint(db.nextID)
+		}
+		if len(db.filter(table, map[string]interface{}{idField: stored[idField]})) > 0 {
+			return inserted, &libraryErrors.AlreadyExistError{Message: "duplicated"}
+		}
+		db.tables[table] = append(db.tables[table], stored)
+		inserted = append(inserted, copyDocument(stored))
+	}
+	return inserted, nil
+}
+
+func (db *memoryDatabase) FindOne(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+	results, err := db.FindMany(table, timeout, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, &libraryErrors.NotExistError{Message: "not found"}
+	}
+	return results[0], nil
+}
+
+func (db *memoryDatabase) FindMany(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.check(timeout); err != nil {
+		return nil, err
+	}
+	var results []map[string]interface{}
+	for _, index := range db.filter(table, filter) {
+		results = append(results, copyDocument(db.tables[table][index]))
+	}
+	return results, nil
+}
+
+func (db *memoryDatabase) UpdateOne(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+	results, err := db.update(table, timeout, filter, newData, false)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (db *memoryDatabase) UpdateMany(table string, timeout int64, filter map[string]interface{}, newData interface{}) ([]map[string]interface{}, error) {
+	return db.update(table, timeout, filter, newData, true)
+}
+
+func (db *memoryDatabase) DeleteOne(table string, timeout int64, filter map[string]interface{}) error {
+	deleted, err := db.delete(table, timeout, filter, false)
+	if err == nil && deleted == 0 {
+		return &libraryErrors.NotExistError{Message: "not found"}
+	}
+	return err
+}
+
+func (db *memoryDatabase) DeleteMany(table string, timeout int64, filter map[string]interface{}) (int, error) {
+	return db.delete(table, timeout, filter, true)
+}
+
+func (db *memoryDatabase) update(table string, timeout int64, filter map[string]interface{}, newData interface{}, many bool) ([]map[string]interface{}, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.check(timeout); err != nil {
+		return nil, err
+	}
+	indexes := db.filter(table, filter)
+	if len(indexes) == 0 {
+		return nil, &libraryErrors.NotExistError{Message: "not found"}
+	}
+	if !many {
+		indexes = indexes[:1]
+	}
+	var results []map[string]interface{}
+	for _, index := range indexes {
+		for key, value := range newData.(map[string]interface{}) {
+			db.tables[table][index][key] = value
+		}
+		results = append(results, copyDocument(db.tables[table][index]))
+	}
+	return results, nil
+}
+
+func (db *memoryDatabase) delete(table string, timeout int64, filter map[string]interface{}, many bool) (int, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.check(timeout); err != nil {
+		return 0, err
+	}
+	indexes := db.filter(table, filter)
+	if !many && len(indexes) > 1 {
+		indexes = indexes[:1]
+	}
+	for i := len(indexes) - 1; i >= 0; i-- {
+		documents := db.tables[table]
+		db.tables[table] = append(documents[:indexes[i]], documents[indexes[i]+1:]...)
+	}
+	return len(indexes), nil
+}
+
+// check validates the timeout and the connection, in the same order as the Managers
+func (db *memoryDatabase) check(timeout int64) error {
+	if timeout < 1 {
+		return &libraryErrors.InputError{Message: "invalid timeout"}
+	}
+	if !db.connected {
+		return &libraryErrors.ClientError{Message: "not connected"}
+	}
+	return nil
+}
+
+// filter returns the indexes of the documents of the table that match the filter
+func (db *memoryDatabase) filter(table string, filter map[string]interface{}) []int {
+	var indexes []int
+	for index, document := range db.tables[table] {
+		matches := true
+		for key, value := range filter {
+			matches = matches && document[key] == value
+		}
+		if matches {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+func copyDocument(document map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(document))
+	for key, value := range document {
+		result[key] = value
+	}
+	return result
+}
+
+func TestRunSuiteMemory(t *testing.T) {
+	tables := make(map[string][]map[string]interface{})
+	RunSuite(t, func() database.DatabaseInterface {
+		return &memoryDatabase{connected: true, tables: tables}
+	})
+}
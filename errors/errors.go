@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	goErrors "errors"
+	"fmt"
+)
 
 type ConnectionError struct {
 	Db string
@@ -34,10 +37,65 @@ func (e *NotExistError) Error() string {
 	return e.Message
 }
 
-type InputError struct {
+// FieldViolation is a field of a document that does not follow the schema of its table
+type FieldViolation struct {
+	Field   string
 	Message string
 }
 
+type InputError struct {
+	Message    string
+	Violations []FieldViolation
+}
+
 func (e *InputError) Error() string {
 	return e.Message
 }
+
+type CircuitOpenError struct {
+	Message string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return e.Message
+}
+
+type ShutdownError struct {
+	Message string
+}
+
+func (e *ShutdownError) Error() string {
+	return e.Message
+}
+
+// Type returns the name of the type of err if it is one of the errors of this package, "UnknownError" if it
+// is another error and an empty string if err is nil. It is useful to classify the errors in logs and metrics
+func Type(err error) string {
+	var connectionError *ConnectionError
+	var clientError *ClientError
+	var alreadyExistError *AlreadyExistError
+	var notExistError *NotExistError
+	var inputError *InputError
+	var circuitOpenError *CircuitOpenError
+	var shutdownError *ShutdownError
+	switch {
+	case err == nil:
+		return ""
+	case goErrors.As(err, &connectionError):
+		return "ConnectionError"
+	case goErrors.As(err, &clientError):
+		return "ClientError"
+	case goErrors.As(err, &alreadyExistError):
+		return "AlreadyExistError"
+	case goErrors.As(err, &notExistError):
+		return "NotExistError"
+	case goErrors.As(err, &inputError):
+		return "InputError"
+	case goErrors.As(err, &circuitOpenError):
+		return "CircuitOpenError"
+	case goErrors.As(err, &shutdownError):
+		return "ShutdownError"
+	default:
+		return "UnknownError"
+	}
+}
@@ -0,0 +1,19 @@
+package fixtures
+
+const (
+	referencePrefix = "$ref:"
+	idField         = "_id"
+
+	fileMessage      = "Fixture file %s can not be read: %v"
+	extensionMessage = "Invalid fixture file %s. It must be YAML or JSON"
+	structureMessage = "Invalid fixtures: %s must be a map of tables with a map of fixtures per table"
+	duplicateMessage = "The fixture %s is defined twice"
+	nameMessage      = "Invalid fixture name %s. It can not contain dots, which separate the field of the references"
+	referenceMessage = "The fixture %s references %s, which is not defined"
+	fieldMessage     = "The fixture %s references the field %s of %s, which is not defined"
+	cycleMessage     = "The fixtures have a cycle of references: %s"
+	databaseMessage  = "Database of the fixtures is not defined"
+	timeoutMessage   = "Invalid timeout: %d. It must be higher than 0"
+	truncateMessage  = "The table %s can not be truncated: %w"
+	insertMessage    = "The fixture %s can not be inserted: %w"
+)
@@ -0,0 +1,254 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is a document to insert in a table before a test
+// Table: It is the table of the document
+// Name: It is the name of the fixture, unique among all the fixtures loaded together. It can not contain dots
+// Document: It is the document. The strings "$ref:<name>" and "$ref:<name>.<field>" are replaced by the _id or by
+// the field of the fixture <name> once it is inserted
+type Fixture struct {
+	Table    string
+	Name     string
+	Document map[string]interface{}
+}
+
+// Parse reads the fixtures of a YAML or JSON file. The file is a map of tables, each one with a map of fixtures by
+// name. The values can be Extended JSON ({"$oid": ...}, {"$date": ...}) also in YAML
+// content: It is the content of the file
+// format: It is the format of the file: yaml or json
+// It returns the fixtures, in the order of the file, and an InputError if the file is not valid
+func Parse(content []byte, format string) ([]Fixture, error) {
+	switch format {
+	case "yaml", "yml":
+		return parseYAML(content)
+	case "json":
+		return parseJSON(content)
+	default:
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(extensionMessage, format)}
+	}
+}
+
+// ReadFiles reads the fixtures of YAML (.yaml, .yml) and JSON (.json) files
+// paths: They are the paths of the files
+// It returns the fixtures, in the order of the files, and an InputError if some file is not valid
+func ReadFiles(paths ...string) ([]Fixture, error) {
+	var fixtures []Fixture
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(fileMessage, path, err)}
+		}
+		format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format != "yaml" && format != "yml" && format != "json" {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(extensionMessage, path)}
+		}
+		parsed, err := Parse(content, format)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, parsed...)
+	}
+	return fixtures, nil
+}
+
+// Load reads the fixture files and inserts their fixtures with Insert
+// db: It is the database of the tables
+// timeout: It is the timeout of each operation in seconds
+// paths: They are the paths of the YAML and JSON files
+// It returns the inserted documents by fixture name and an error
+func Load(db database.DatabaseInterface, timeout int64, paths ...string) (map[string]map[string]interface{}, error) {
+	fixtures, err := ReadFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+	return Insert(db, timeout, fixtures)
+}
+
+// Insert truncates the tables of the fixtures and inserts the fixtures, with their references resolved. A fixture
+// is inserted after the fixtures that it references, and in the order of the list otherwise
+// db: It is the database of the tables
+// timeout: It is the timeout of each operation in seconds
+// fixtures: They are the fixtures to insert
+// It returns the inserted documents, as returned by InsertOne, by fixture name and an error
+func Insert(db database.DatabaseInterface, timeout int64, fixtures []Fixture) (map[string]map[string]interface{}, error) {
+	if db == nil {
+		return nil, &libraryErrors.InputError{Message: databaseMessage}
+	}
+	if timeout < 1 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
+	}
+	ordered, err := order(fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := make(map[string]bool)
+	for _, fixture := range fixtures {
+		if truncated[fixture.Table] {
+			continue
+		}
+		truncated[fixture.Table] = true
+		_, err := db.DeleteMany(fixture.Table, timeout, map[string]interface{}{})
+		var notExistError *libraryErrors.NotExistError
+		if err != nil && !errors.As(err, &notExistError) {
+			return nil, fmt.Errorf(truncateMessage, fixture.Table, err)
+		}
+	}
+
+	loaded := make(map[string]map[string]interface{}, len(ordered))
+	for _, fixture := range ordered {
+		document, err := resolve(fixture.Name, fixture.Document, loaded)
+		if err != nil {
+			return loaded, err
+		}
+		inserted, err := db.InsertOne(fixture.Table, timeout, document.(map[string]interface{}))
+		if err != nil {
+			return loaded, fmt.Errorf(insertMessage, fixture.Name, err)
+		}
+		loaded[fixture.Name] = inserted
+	}
+	return loaded, nil
+}
+
+// parseYAML reads the tables and the fixtures with yaml.Node, to keep the order of the file
+func parseYAML(content []byte) ([]Fixture, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, &libraryErrors.InputError{Message: err.Error()}
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	tables := root.Content[0]
+	if tables.Kind != yaml.MappingNode {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(structureMessage, "the file")}
+	}
+
+	var fixtures []Fixture
+	for i := 0; i+1 < len(tables.Content); i += 2 {
+		table, names := tables.Content[i].Value, tables.Content[i+1]
+		if names.Kind != yaml.MappingNode {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(structureMessage, table)}
+		}
+		for j := 0; j+1 < len(names.Content); j += 2 {
+			var document map[string]interface{}
+			if err := names.Content[j+1].Decode(&document); err != nil {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(structureMessage, table+"."+names.Content[j].Value)}
+			}
+			converted, err := convertExtendedJSON(document)
+			if err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, Fixture{Table: table, Name: names.Content[j].Value, Document: converted.(map[string]interface{})})
+		}
+	}
+	return fixtures, nil
+}
+
+// parseJSON reads the tables and the fixtures as Extended JSON with bson.D, to keep the order of the file
+func parseJSON(content []byte) ([]Fixture, error) {
+	var tables bson.D
+	if err := bson.UnmarshalExtJSON(content, false, &tables); err != nil {
+		return nil, &libraryErrors.InputError{Message: err.Error()}
+	}
+	var fixtures []Fixture
+	for _, table := range tables {
+		names, ok := table.Value.(bson.D)
+		if !ok {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(structureMessage, table.Key)}
+		}
+		for _, name := range names {
+			document, ok := name.Value.(bson.D)
+			if !ok {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(structureMessage, table.Key+"."+name.Key)}
+			}
+			fixtures = append(fixtures, Fixture{Table: table.Key, Name: name.Key, Document: toMap(document)})
+		}
+	}
+	return fixtures, nil
+}
+
+// toMap converts a bson.D and its embedded documents to maps
+func toMap(document bson.D) map[string]interface{} {
+	result := make(map[string]interface{}, len(document))
+	for _, element := range document {
+		result[element.Key] = toMapValue(element.Value)
+	}
+	return result
+}
+
+// toMapValue converts the embedded documents of a value to maps
+func toMapValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case bson.D:
+		return toMap(typed)
+	case primitive.A:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = toMapValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// convertExtendedJSON replaces the maps of the YAML documents whose keys are Extended JSON operators ($oid,
+// $date...) by the values that they represent
+func convertExtendedJSON(value interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		operators := len(typed) > 0
+		for key := range typed {
+			operators = operators && strings.HasPrefix(key, "$")
+		}
+		if operators {
+			content, err := json.Marshal(map[string]interface{}{"v": typed})
+			if err != nil {
+				return nil, &libraryErrors.InputError{Message: err.Error()}
+			}
+			var wrapper struct {
+				Value interface{} `bson:"v"`
+			}
+			if err := bson.UnmarshalExtJSON(content, false, &wrapper); err != nil {
+				return nil, &libraryErrors.InputError{Message: err.Error()}
+			}
+			return toMapValue(wrapper.Value), nil
+		}
+		result := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted, err := convertExtendedJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted, err := convertExtendedJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,128 @@
+package fixtures
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	timeoutTest = 5
+	yamlTest    = `
+orders:
+  order1:
+    user_id: $ref:alice
+    city: $ref:alice.address.city
+    items: [$ref:pen]
+users:
+  alice:
+    _id: {"$oid": "5f1d7f3e9d3b2a1c4e8b4567"}
+    name: Alice
+    address:
+      city: x
+products:
+  pen:
+    price: 1.5
+`
+	jsonTest = `{"users": {"bob": {"name": "Bob", "created": {"$date": "2024-01-02T03:04:05Z"}, "tags": [{"a": 1}]}}}`
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// initializeMock returns a mock that assigns a numeric _id to the documents without it and records the operations
+func initializeMock(operations *[]string) *database.DatabaseInterfaceMock {
+	next := 0
+	return &database.DatabaseInterfaceMock{
+		DeleteManyFunc: func(table string, timeout int64, filter map[string]interface{}) (int, error) {
+			*operations = append(*operations, "truncate "+table)
+			return 0, &libraryErrors.NotExistError{}
+		},
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			*operations = append(*operations, "insert "+table)
+			if _, found := data["_id"]; !found {
+				next++
+				data["_id"] = next
+			}
+			return data, nil
+		},
+	}
+}
+
+func TestParseSuccess(t *testing.T) {
+	fixtures, err := Parse([]byte(yamlTest), "yaml")
+	assert.NoError(t, err)
+	assert.Len(t, fixtures, 3)
+	assert.Equal(t, "order1", fixtures[0].Name)
+	assert.Equal(t, "orders", fixtures[0].Table)
+	assert.IsType(t, primitive.ObjectID{}, fixtures[1].Document["_id"])
+
+	fixtures, err = Parse([]byte(jsonTest), "json")
+	assert.NoError(t, err)
+	assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), fixtures[0].Document["created"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"a": int32(1)}}, fixtures[0].Document["tags"])
+}
+
+func TestParseFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	_, err := Parse([]byte("users: [1, 2]"), "yaml")
+	assert.ErrorAs(t, err, &inputError)
+	_, err = Parse([]byte(`{"users": {"bob": 1}}`), "json")
+	assert.ErrorAs(t, err, &inputError)
+	_, err = Parse([]byte("{}"), "xml")
+	assert.ErrorAs(t, err, &inputError)
+	_, err = ReadFiles(writeFile(t, "fixtures.txt", yamlTest))
+	assert.ErrorAs(t, err, &inputError)
+	_, err = ReadFiles(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorAs(t, err, &inputError)
+}
+
+func TestLoadSuccess(t *testing.T) {
+	var operations []string
+	loaded, err := Load(initializeMock(&operations), timeoutTest, writeFile(t, "fixtures.yaml", yamlTest), writeFile(t, "fixtures.json", jsonTest))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"truncate orders", "truncate users", "truncate products",
+		"insert users", "insert products", "insert orders", "insert users",
+	}, operations)
+
+	alice := loaded["alice"]
+	assert.Equal(t, alice["_id"], loaded["order1"]["user_id"])
+	assert.Equal(t, "x", loaded["order1"]["city"])
+	assert.Equal(t, []interface{}{loaded["pen"]["_id"]}, loaded["order1"]["items"])
+	assert.Equal(t, "Bob", loaded["bob"]["name"])
+}
+
+func TestInsertFailed(t *testing.T) {
+	var operations []string
+	var inputError *libraryErrors.InputError
+	mock := initializeMock(&operations)
+
+	_, err := Insert(nil, timeoutTest, nil)
+	assert.ErrorAs(t, err, &inputError)
+	_, err = Insert(mock, 0, nil)
+	assert.ErrorAs(t, err, &inputError)
+
+	mock.InsertOneFunc = func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+		return nil, &libraryErrors.AlreadyExistError{Message: "duplicated"}
+	}
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	_, err = Insert(mock, timeoutTest, []Fixture{{Table: "users", Name: "a", Document: map[string]interface{}{}}})
+	assert.ErrorAs(t, err, &alreadyExistError)
+
+	mock.DeleteManyFunc = func(table string, timeout int64, filter map[string]interface{}) (int, error) {
+		return 0, fmt.Errorf("failure")
+	}
+	_, err = Insert(mock, timeoutTest, []Fixture{{Table: "users", Name: "a", Document: map[string]interface{}{}}})
+	assert.ErrorContains(t, err, "failure")
+}
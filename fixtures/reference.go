@@ -0,0 +1,144 @@
+package fixtures
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/internal/bsonutil"
+)
+
+// order sorts the fixtures so each one comes after the fixtures that it references. The fixtures without
+// dependencies between them keep the order of the list. The names with dots are rejected, because the dot separates
+// the name and the field of the references
+func order(fixtures []Fixture) ([]Fixture, error) {
+	byName := make(map[string]int, len(fixtures))
+	for i, fixture := range fixtures {
+		if strings.Contains(fixture.Name, ".") {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(nameMessage, fixture.Name)}
+		}
+		if _, found := byName[fixture.Name]; found {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(duplicateMessage, fixture.Name)}
+		}
+		byName[fixture.Name] = i
+	}
+
+	const (
+		pending = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(fixtures))
+	ordered := make([]Fixture, 0, len(fixtures))
+	var visit func(index int, path []string) error
+	visit = func(index int, path []string) error {
+		fixture := fixtures[index]
+		switch states[index] {
+		case visited:
+			return nil
+		case visiting:
+			return &libraryErrors.InputError{Message: fmt.Sprintf(cycleMessage, strings.Join(append(path, fixture.Name), " -> "))}
+		}
+		states[index] = visiting
+		for _, name := range references(fixture.Document) {
+			dependency, found := byName[name]
+			if !found {
+				return &libraryErrors.InputError{Message: fmt.Sprintf(referenceMessage, fixture.Name, name)}
+			}
+			if err := visit(dependency, append(path, fixture.Name)); err != nil {
+				return err
+			}
+		}
+		states[index] = visited
+		ordered = append(ordered, fixture)
+		return nil
+	}
+	for i := range fixtures {
+		if err := visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// references returns the names of the fixtures referenced by a value, in a stable order
+func references(value interface{}) []string {
+	var names []string
+	switch typed := value.(type) {
+	case string:
+		if name, _, ok := parseReference(typed); ok {
+			names = append(names, name)
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(typed) {
+			names = append(names, references(typed[key])...)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			names = append(names, references(item)...)
+		}
+	}
+	return names
+}
+
+// resolve returns a copy of the value with the references replaced by the values of the loaded fixtures
+func resolve(fixture string, value interface{}, loaded map[string]map[string]interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		name, field, ok := parseReference(typed)
+		if !ok {
+			return typed, nil
+		}
+		resolved, found := bsonutil.GetField(loaded[name], field)
+		if !found {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(fieldMessage, fixture, field, name)}
+		}
+		return resolved, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			resolved, err := resolve(fixture, item, loaded)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			resolved, err := resolve(fixture, item, loaded)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = resolved
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
+
+// parseReference splits "$ref:<name>.<field>" into the name and the field (_id when it is not defined)
+func parseReference(text string) (string, string, bool) {
+	reference, ok := strings.CutPrefix(text, referencePrefix)
+	if !ok || reference == "" {
+		return "", "", false
+	}
+	name, field, found := strings.Cut(reference, ".")
+	if !found || field == "" {
+		field = idField
+	}
+	return name, field, true
+}
+
+// sortedKeys returns the keys of the map sorted
+func sortedKeys(document map[string]interface{}) []string {
+	keys := make([]string, 0, len(document))
+	for key := range document {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
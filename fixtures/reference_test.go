@@ -0,0 +1,55 @@
+package fixtures
+
+import (
+	"fmt"
+	"testing"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderSuccess(t *testing.T) {
+	ordered, err := order([]Fixture{
+		{Name: "c", Document: map[string]interface{}{"b": "$ref:b", "a": "$ref:a.name"}},
+		{Name: "a", Document: map[string]interface{}{}},
+		{Name: "b", Document: map[string]interface{}{"a": []interface{}{"$ref:a"}}},
+		{Name: "d", Document: map[string]interface{}{"text": "$ref:"}},
+	})
+	assert.NoError(t, err)
+	names := make([]string, len(ordered))
+	for i, fixture := range ordered {
+		names[i] = fixture.Name
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+}
+
+func TestOrderFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	_, err := order([]Fixture{{Name: "a"}, {Name: "a"}})
+	assert.ErrorAs(t, err, &inputError)
+
+	_, err = order([]Fixture{{Name: "a", Document: map[string]interface{}{"b": "$ref:b"}}})
+	assert.ErrorAs(t, err, &inputError)
+
+	_, err = order([]Fixture{
+		{Name: "a", Document: map[string]interface{}{"b": "$ref:b"}},
+		{Name: "b", Document: map[string]interface{}{"a": "$ref:a"}},
+	})
+	assert.ErrorAs(t, err, &inputError)
+	assert.Contains(t, err.Error(), "a -> b -> a")
+
+	_, err = order([]Fixture{{Name: "user.admin"}})
+	assert.ErrorAs(t, err, &inputError)
+	assert.Equal(t, fmt.Sprintf(nameMessage, "user.admin"), inputError.Message)
+}
+
+func TestResolveFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	loaded := map[string]map[string]interface{}{"a": {"_id": 1}}
+	_, err := resolve("b", map[string]interface{}{"x": "$ref:a.missing"}, loaded)
+	assert.ErrorAs(t, err, &inputError)
+
+	resolved, err := resolve("b", map[string]interface{}{"x": "$ref:a", "y": "text"}, loaded)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"x": 1, "y": "text"}, resolved)
+}
@@ -0,0 +1,124 @@
+// Package bsonutil contains the helpers to read, sort and format the fields of the documents shared by the
+// packages of the module
+package bsonutil
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const idField = "_id"
+
+// GetField returns the value of a field, following the dots of the embedded documents
+// document: It is the document with the field
+// field: It is the name of the field. The fields of embedded documents are separated by dots (address.city)
+// It returns the value and if the document contains the field
+func GetField(document map[string]interface{}, field string) (interface{}, bool) {
+	var current interface{} = document
+	for _, key := range strings.Split(field, ".") {
+		switch typed := current.(type) {
+		case map[string]interface{}:
+			value, found := typed[key]
+			if !found {
+				return nil, false
+			}
+			current = value
+		case primitive.M:
+			value, found := typed[key]
+			if !found {
+				return nil, false
+			}
+			current = value
+		case primitive.D:
+			found := false
+			for _, element := range typed {
+				if element.Key == key {
+					current, found = element.Value, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SortedFields returns the fields of the documents sorted, with _id first
+// documents: They are the documents with the fields
+// It returns the names of the fields of the first level, without duplicates
+func SortedFields(documents ...map[string]interface{}) []string {
+	fields := make(map[string]bool)
+	for _, document := range documents {
+		for field := range document {
+			fields[field] = true
+		}
+	}
+	sorted := make([]string, 0, len(fields))
+	for field := range fields {
+		sorted = append(sorted, field)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i] == idField || sorted[j] == idField {
+			return sorted[i] == idField
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// OrderDocument converts the document to a bson.D with _id first and the rest of the fields sorted, so the
+// Extended JSON of the same documents is identical
+// document: It is the document to order
+// It returns the ordered document
+func OrderDocument(document map[string]interface{}) bson.D {
+	ordered := make(bson.D, 0, len(document))
+	for _, field := range SortedFields(document) {
+		ordered = append(ordered, bson.E{Key: field, Value: document[field]})
+	}
+	return ordered
+}
+
+// FormatValue converts a value to text. The strings are kept, the ObjectIDs are hexadecimal, the dates are
+// RFC 3339 and the rest of the values are relaxed Extended JSON
+// value: It is the value to convert
+// It returns the text and an error if the value can not be converted to Extended JSON
+func FormatValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	case int32:
+		return strconv.FormatInt(int64(typed), 10), nil
+	case int64:
+		return strconv.FormatInt(typed, 10), nil
+	case float64:
+		return strconv.FormatFloat(typed, 'g', -1, 64), nil
+	case primitive.ObjectID:
+		return typed.Hex(), nil
+	case primitive.DateTime:
+		return typed.Time().UTC().Format(time.RFC3339Nano), nil
+	case time.Time:
+		return typed.UTC().Format(time.RFC3339Nano), nil
+	}
+	content, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return "", err
+	}
+	content = bytes.TrimPrefix(content, []byte(`{"v":`))
+	return string(bytes.TrimSuffix(content, []byte("}"))), nil
+}
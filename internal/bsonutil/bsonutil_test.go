@@ -0,0 +1,59 @@
+package bsonutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetField(t *testing.T) {
+	document := map[string]interface{}{
+		"age":     int32(1),
+		"address": map[string]interface{}{"city": "x", "geo": bson.D{{Key: "lat", Value: 40.4}}, "zip": nil},
+		"tags":    bson.A{"a"},
+	}
+	value, found := GetField(document, "address.city")
+	assert.True(t, found)
+	assert.Equal(t, "x", value)
+	value, found = GetField(document, "address.geo.lat")
+	assert.True(t, found)
+	assert.Equal(t, 40.4, value)
+	value, found = GetField(document, "address.zip")
+	assert.True(t, found)
+	assert.Nil(t, value)
+
+	for _, field := range []string{"name", "address.street", "age.value", "tags.0"} {
+		_, found = GetField(document, field)
+		assert.False(t, found, field)
+	}
+}
+
+func TestSortedFields(t *testing.T) {
+	assert.Equal(t, []string{"_id", "a", "b"}, SortedFields(map[string]interface{}{"b": 1, "_id": 1}, map[string]interface{}{"a": 1, "b": 2}))
+	assert.Equal(t, bson.D{{Key: "_id", Value: 1}, {Key: "a", Value: 2}}, OrderDocument(map[string]interface{}{"a": 2, "_id": 1}))
+}
+
+func TestFormatValue(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex("5f1d7f3e9d3b2a1c4e8b4567")
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for value, expected := range map[interface{}]string{
+		nil:                                 "",
+		"text":                              "text",
+		true:                                "true",
+		int32(3):                            "3",
+		1.5:                                 "1.5",
+		id:                                  "5f1d7f3e9d3b2a1c4e8b4567",
+		primitive.NewDateTimeFromTime(date): "2024-01-02T03:04:05Z",
+		date:                                "2024-01-02T03:04:05Z",
+	} {
+		text, err := FormatValue(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, text)
+	}
+	text, err := FormatValue(primitive.A{"x", int32(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, `["x",1]`, text)
+}
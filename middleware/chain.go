@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Chain is the structure implementing the DatabaseInterface that runs every operation through a list of Middleware
+// database: It is the database that executes the operations at the end of the Chain
+// handler: It is the result of wrapping the database with all the Middleware
+// ctx: It is the context added to every Operation
+type Chain struct {
+	database database.DatabaseInterface
+	handler  Handler
+	ctx      context.Context
+}
+
+// CreateChain is the constructor for the Chain
+// db: It is the database that executes the operations
+// middlewares: They are the Middleware to run. The first one is the outermost one
+// It returns the Chain instance
+func CreateChain(db database.DatabaseInterface, middlewares ...Middleware) *Chain {
+	chain := &Chain{database: db, ctx: context.Background()}
+	handler := chain.execute
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	chain.handler = handler
+	return chain
+}
+
+// WithContext returns a copy of the Chain that adds ctx to every Operation
+func (chain *Chain) WithContext(ctx context.Context) *Chain {
+	chainCopy := *chain
+	chainCopy.ctx = ctx
+	return &chainCopy
+}
+
+// ConnectDb is the function to connect the database through the Chain. The Middleware receive the URI with its
+// password redacted
+func (chain *Chain) ConnectDb(dbURI, dbName string, timeout int64) error {
+	operation := chain.newOperation(OperationConnectDb, dbName, timeout, nil, redactURI(dbURI))
+	operation.dbURI = dbURI
+	chain.handler(operation)
+	return operation.Err
+}
+
+// DisconnectDb is the function to disconnect the database through the Chain
+func (chain *Chain) DisconnectDb() error {
+	return chain.run(OperationDisconnectDb, "", 0, nil, nil).Err
+}
+
+// InsertOne is the function to insert a document through the Chain
+func (chain *Chain) InsertOne(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+	operation := chain.run(OperationInsertOne, table, timeout, nil, data)
+	result, _ := operation.Result.(map[string]interface{})
+	return result, operation.Err
+}
+
+// InsertMany is the function to insert many documents through the Chain
+func (chain *Chain) InsertMany(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+	operation := chain.run(OperationInsertMany, table, timeout, nil, data)
+	result, _ := operation.Result.([]map[string]interface{})
+	return result, operation.Err
+}
+
+// FindOne is the function to find a document through the Chain
+func (chain *Chain) FindOne(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+	operation := chain.run(OperationFindOne, table, timeout, filter, nil)
+	result, _ := operation.Result.(map[string]interface{})
+	return result, operation.Err
+}
+
+// FindMany is the function to find many documents through the Chain
+func (chain *Chain) FindMany(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+	operation := chain.run(OperationFindMany, table, timeout, filter, nil)
+	result, _ := operation.Result.([]map[string]interface{})
+	return result, operation.Err
+}
+
+// UpdateOne is the function to update a document through the Chain
+func (chain *Chain) UpdateOne(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+	operation := chain.run(OperationUpdateOne, table, timeout, filter, newData)
+	result, _ := operation.Result.(map[string]interface{})
+	return result, operation.Err
+}
+
+// UpdateMany is the function to update many documents through the Chain
+func (chain *Chain) UpdateMany(table string, timeout int64, filter map[string]interface{}, newData interface{}) ([]map[string]interface{}, error) {
+	operation := chain.run(OperationUpdateMany, table, timeout, filter, newData)
+	result, _ := operation.Result.([]map[string]interface{})
+	return result, operation.Err
+}
+
+// DeleteOne is the function to delete a document through the Chain
+func (chain *Chain) DeleteOne(table string, timeout int64, filter map[string]interface{}) error {
+	return chain.run(OperationDeleteOne, table, timeout, filter, nil).Err
+}
+
+// DeleteMany is the function to delete many documents through the Chain
+func (chain *Chain) DeleteMany(table string, timeout int64, filter map[string]interface{}) (int, error) {
+	operation := chain.run(OperationDeleteMany, table, timeout, filter, nil)
+	result, _ := operation.Result.(int)
+	return result, operation.Err
+}
+
+// run creates the Operation and executes it through all the Middleware
+func (chain *Chain) run(name, table string, timeout int64, filter map[string]interface{}, data interface{}) *Operation {
+	operation := chain.newOperation(name, table, timeout, filter, data)
+	chain.handler(operation)
+	return operation
+}
+
+// newOperation creates the Operation of a call to the Chain
+func (chain *Chain) newOperation(name, table string, timeout int64, filter map[string]interface{}, data interface{}) *Operation {
+	return &Operation{
+		Context: chain.ctx,
+		Name:    name,
+		Table:   table,
+		Timeout: timeout,
+		Filter:  filter,
+		Data:    data,
+		Start:   time.Now(),
+	}
+}
+
+// redactURI returns the URI with the password of the credentials and the values of the parameters that contain
+// "password" (like tlsCertificateKeyFilePassword) replaced, so it can be logged
+func redactURI(dbURI string) string {
+	scheme, rest, found := strings.Cut(dbURI, "://")
+	if !found {
+		return redactedValue
+	}
+	authority, path := rest, ""
+	if index := strings.IndexAny(rest, "/?"); index >= 0 {
+		authority, path = rest[:index], rest[index:]
+	}
+	if index := strings.LastIndex(authority, "@"); index >= 0 {
+		if username, _, found := strings.Cut(authority[:index], ":"); found {
+			authority = username + ":" + redactedValue + authority[index:]
+		}
+	}
+	path, query, found := strings.Cut(path, "?")
+	if found {
+		parameters := strings.Split(query, "&")
+		for i, parameter := range parameters {
+			if key, _, found := strings.Cut(parameter, "="); found && strings.Contains(strings.ToLower(key), "password") {
+				parameters[i] = key + "=" + redactedValue
+			}
+		}
+		path += "?" + strings.Join(parameters, "&")
+	}
+	return scheme + "://" + authority + path
+}
+
+// execute is the last Handler of the Chain, which calls the database
+func (chain *Chain) execute(operation *Operation) {
+	start := time.Now()
+	defer func() {
+		operation.Duration = time.Since(start)
+	}()
+
+	switch operation.Name {
+	case OperationConnectDb:
+		operation.Err = chain.database.ConnectDb(operation.dbURI, operation.Table, operation.Timeout)
+	case OperationDisconnectDb:
+		operation.Err = chain.database.DisconnectDb()
+	case OperationInsertOne:
+		data, _ := operation.Data.(map[string]interface{})
+		operation.Result, operation.Err = chain.database.InsertOne(operation.Table, operation.Timeout, data)
+	case OperationInsertMany:
+		data, _ := operation.Data.([]map[string]interface{})
+		operation.Result, operation.Err = chain.database.InsertMany(operation.Table, operation.Timeout, data)
+	case OperationFindOne:
+		operation.Result, operation.Err = chain.database.FindOne(operation.Table, operation.Timeout, operation.Filter)
+	case OperationFindMany:
+		operation.Result, operation.Err = chain.database.FindMany(operation.Table, operation.Timeout, operation.Filter)
+	case OperationUpdateOne:
+		operation.Result, operation.Err = chain.database.UpdateOne(operation.Table, operation.Timeout, operation.Filter, operation.Data)
+	case OperationUpdateMany:
+		operation.Result, operation.Err = chain.database.UpdateMany(operation.Table, operation.Timeout, operation.Filter, operation.Data)
+	case OperationDeleteOne:
+		operation.Err = chain.database.DeleteOne(operation.Table, operation.Timeout, operation.Filter)
+	case OperationDeleteMany:
+		operation.Result, operation.Err = chain.database.DeleteMany(operation.Table, operation.Timeout, operation.Filter)
+	default:
+		operation.Err = &libraryErrors.InputError{Message: fmt.Sprintf(unknownOperationMessage, operation.Name)}
+	}
+}
@@ -0,0 +1,7 @@
+package middleware
+
+const (
+	redactedValue = "[REDACTED]"
+
+	unknownOperationMessage = "Unknown operation: %s"
+)
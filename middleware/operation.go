@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"time"
+)
+
+// Names of the operations of the DatabaseInterface
+const (
+	OperationConnectDb    = "ConnectDb"
+	OperationDisconnectDb = "DisconnectDb"
+	OperationInsertOne    = "InsertOne"
+	OperationInsertMany   = "InsertMany"
+	OperationFindOne      = "FindOne"
+	OperationFindMany     = "FindMany"
+	OperationUpdateOne    = "UpdateOne"
+	OperationUpdateMany   = "UpdateMany"
+	OperationDeleteOne    = "DeleteOne"
+	OperationDeleteMany   = "DeleteMany"
+)
+
+// Operation is the description of a call to the DatabaseInterface
+// Context: It is the context bound to the Chain that received the call
+// Name: It is the name of the operation (OperationInsertOne, OperationFindMany...)
+// Table: It is the table of the operation. For ConnectDb, it is the name of the DB
+// Timeout: It is the timeout of the operation
+// Filter: It is the filter of the operation, if any
+// Data: It is the payload of the operation: the document(s) to insert, the new data of an update
+// or the URI for ConnectDb, with its password and the password parameters redacted
+// Start: It is the moment when the Chain received the call
+// Duration: It is the time spent by the database. It is 0 if the operation was short-circuited
+// Result: It is the result returned by the database (map, list of maps or number of documents deleted)
+// Err: It is the error returned by the database or by a middleware
+// dbURI: It is the URI of ConnectDb, with its credentials. It is kept out of Data so the Middleware do not see it
+type Operation struct {
+	Context  context.Context
+	Name     string
+	Table    string
+	Timeout  int64
+	Filter   map[string]interface{}
+	Data     interface{}
+	Start    time.Time
+	Duration time.Duration
+	Result   interface{}
+	Err      error
+	dbURI    string
+}
+
+// Handler is a function that executes an Operation, filling its Result and Err
+type Handler func(operation *Operation)
+
+// Middleware is a function that wraps a Handler. It may run code before and after calling next,
+// or short-circuit the Operation by setting Result and Err without calling next
+type Middleware func(next Handler) Handler
+
+// Before creates a Middleware that runs the hook before the Operation. If the hook returns an error,
+// the Operation is short-circuited with that error
+func Before(hook func(operation *Operation) error) Middleware {
+	return func(next Handler) Handler {
+		return func(operation *Operation) {
+			if err := hook(operation); err != nil {
+				operation.Err = err
+				return
+			}
+			next(operation)
+		}
+	}
+}
+
+// After creates a Middleware that runs the hook after the Operation, with the Result and Err filled
+func After(hook func(operation *Operation)) Middleware {
+	return func(next Handler) Handler {
+		return func(operation *Operation) {
+			next(operation)
+			hook(operation)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import "time"
+
+const (
+	defaultTable   = "migrations"
+	defaultTimeout = 10
+	defaultLockTTL = 15 * time.Minute
+	lockID         = "migrate_lock"
+	kindField      = "kind"
+	kindMigration  = "migration"
+	kindLock       = "lock"
+	idField        = "_id"
+	versionField   = "version"
+	nameField      = "name"
+	appliedAtField = "applied_at"
+	ownerField     = "owner"
+	expiresAtField = "expires_at"
+
+	databaseMissingMessage = "Database of the migrations is not defined"
+	versionMessage         = "Invalid version of the migration %s: %d. It must be higher than 0 and unique"
+	upMissingMessage       = "The migration %d has no Up function"
+	downMissingMessage     = "The migration %d can not be rolled back: it has no Down function"
+	unknownVersionMessage  = "The migration %d is applied but it is not defined"
+	timeoutMessage         = "Invalid timeout: %d. It must be higher than 0"
+	lockTTLMessage         = "Invalid lock TTL: %v. It must be higher or equal than 0"
+	lockedMessage          = "The migrations are locked by %s until %v"
+	lockLostMessage        = "The lock of the migrations of %s has expired or it has been taken by another run"
+	migrationFailedMessage = "Migration %d (%s) failed: %w"
+)
@@ -0,0 +1,341 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Migration is a versioned change of the DB, like creating an index or a validator or backfilling a field
+// Version: It is the number that orders the migrations. It must be unique and higher than 0
+// Name: It is the description of the migration
+// Up: It is the function that applies the migration
+// Down: It is the function that rolls the migration back. If it is nil, the migration can not be rolled back
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      func(ctx context.Context, db database.DatabaseInterface) error
+	Down    func(ctx context.Context, db database.DatabaseInterface) error
+}
+
+// Config is the structure to define the behaviour of the Migrator. The values that are empty use the default ones
+// Table: It is the table with the applied versions and the lock (migrations by default)
+// Timeout: It is the timeout of the operations on the table in seconds (10 by default)
+// LockTTL: It is the time after which the lock of a crashed run can be taken by another run (15 minutes by default).
+// The lock is renewed before each migration, so every migration must finish within it
+// Owner: It is the name of the run in the lock (the host name and the process ID by default)
+// DryRun: It returns the migrations that would run, without running them nor taking the lock
+type Config struct {
+	Table   string
+	Timeout int64
+	LockTTL time.Duration
+	Owner   string
+	DryRun  bool
+}
+
+// Status is the state of a Migration in the DB
+type Status struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator is the structure that runs the migrations against a DatabaseInterface
+// database: It is the database to migrate
+// migrations: They are the migrations, sorted by version
+// config: It is the configuration of the Migrator
+// now: It is the clock of the Migrator
+type Migrator struct {
+	database   database.DatabaseInterface
+	migrations []Migration
+	config     Config
+	now        func() time.Time
+}
+
+// CreateMigrator is the constructor for the Migrator
+// db: It is the database to migrate. It must be connected
+// migrations: They are the migrations, in any order
+// config: It is the configuration of the Migrator
+// It returns the Migrator instance and an InputError if some migration or value is not valid
+func CreateMigrator(db database.DatabaseInterface, migrations []Migration, config Config) (*Migrator, error) {
+	if db == nil {
+		return nil, &libraryErrors.InputError{Message: databaseMissingMessage}
+	}
+	if config.Timeout < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, config.Timeout)}
+	}
+	if config.LockTTL < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(lockTTLMessage, config.LockTTL)}
+	}
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.LockTTL == 0 {
+		config.LockTTL = defaultLockTTL
+	}
+	if config.Owner == "" {
+		hostname, _ := os.Hostname()
+		config.Owner = fmt.Sprintf("%s:%d", hostname, os.Getpid())
+	}
+
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+	for i, migration := range sorted {
+		if migration.Version == 0 || (i > 0 && sorted[i-1].Version == migration.Version) {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(versionMessage, migration.Name, migration.Version)}
+		}
+		if migration.Up == nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(upMissingMessage, migration.Version)}
+		}
+	}
+	return &Migrator{database: db, migrations: sorted, config: config, now: time.Now}, nil
+}
+
+// Status returns the state of every Migration, sorted by version
+// It returns the list of states and an error
+func (migrator *Migrator) Status() ([]Status, error) {
+	applied, err := migrator.applied()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(migrator.migrations))
+	for i, migration := range migrator.migrations {
+		appliedAt, found := applied[migration.Version]
+		statuses[i] = Status{Version: migration.Version, Name: migration.Name, Applied: found, AppliedAt: appliedAt}
+	}
+	return statuses, nil
+}
+
+// Up applies the pending migrations up to the target version, in order. It stops at the first error
+// ctx: It is the context of the migrations
+// target: It is the last version to apply. 0 means all the migrations
+// It returns the migrations applied (or that would be applied in DryRun) and an error
+func (migrator *Migrator) Up(ctx context.Context, target uint64) ([]Migration, error) {
+	return migrator.run(ctx, func(applied map[uint64]time.Time) ([]Migration, error) {
+		var pending []Migration
+		for _, migration := range migrator.migrations {
+			if _, found := applied[migration.Version]; !found && (target == 0 || migration.Version <= target) {
+				pending = append(pending, migration)
+			}
+		}
+		return pending, nil
+	}, true)
+}
+
+// Down rolls back the applied migrations with a version higher than the target, from the newest one. Nothing is
+// rolled back if some of them has no Down function
+// ctx: It is the context of the migrations
+// target: It is the version that remains applied. 0 rolls back all the migrations
+// It returns the migrations rolled back (or that would be rolled back in DryRun) and an error
+func (migrator *Migrator) Down(ctx context.Context, target uint64) ([]Migration, error) {
+	return migrator.run(ctx, func(applied map[uint64]time.Time) ([]Migration, error) {
+		byVersion := make(map[uint64]Migration, len(migrator.migrations))
+		for _, migration := range migrator.migrations {
+			byVersion[migration.Version] = migration
+		}
+		var pending []Migration
+		for version := range applied {
+			if version <= target {
+				continue
+			}
+			migration, found := byVersion[version]
+			if !found {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(unknownVersionMessage, version)}
+			}
+			if migration.Down == nil {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(downMissingMessage, version)}
+			}
+			pending = append(pending, migration)
+		}
+		sort.Slice(pending, func(i, j int) bool {
+			return pending[i].Version > pending[j].Version
+		})
+		return pending, nil
+	}, false)
+}
+
+// run takes the lock, selects the migrations with plan and applies or rolls them back
+func (migrator *Migrator) run(ctx context.Context, plan func(applied map[uint64]time.Time) ([]Migration, error), up bool) ([]Migration, error) {
+	var expiresAt time.Time
+	if !migrator.config.DryRun {
+		var err error
+		if expiresAt, err = migrator.lock(); err != nil {
+			return nil, err
+		}
+		defer migrator.unlock()
+	}
+	applied, err := migrator.applied()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := plan(applied)
+	if err != nil || migrator.config.DryRun {
+		return pending, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+		if expiresAt, err = migrator.renew(expiresAt); err != nil {
+			return done, err
+		}
+		if up {
+			err = migrator.apply(ctx, migration)
+		} else {
+			err = migrator.rollback(ctx, migration)
+		}
+		if err != nil {
+			return done, fmt.Errorf(migrationFailedMessage, migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// apply runs the Up function of the migration and records its version
+func (migrator *Migrator) apply(ctx context.Context, migration Migration) error {
+	if err := migration.Up(ctx, migrator.database); err != nil {
+		return err
+	}
+	_, err := migrator.database.InsertOne(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{
+		idField:        migrationID(migration.Version),
+		kindField:      kindMigration,
+		versionField:   int64(migration.Version),
+		nameField:      migration.Name,
+		appliedAtField: migrator.now().UTC(),
+	})
+	return err
+}
+
+// rollback runs the Down function of the migration and removes its version
+func (migrator *Migrator) rollback(ctx context.Context, migration Migration) error {
+	if err := migration.Down(ctx, migrator.database); err != nil {
+		return err
+	}
+	return migrator.database.DeleteOne(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{idField: migrationID(migration.Version)})
+}
+
+// applied returns the versions recorded in the table with the moment they were applied
+func (migrator *Migrator) applied() (map[uint64]time.Time, error) {
+	documents, err := migrator.database.FindMany(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{kindField: kindMigration})
+	var notExistError *libraryErrors.NotExistError
+	if err != nil && !errors.As(err, &notExistError) {
+		return nil, err
+	}
+	applied := make(map[uint64]time.Time, len(documents))
+	for _, document := range documents {
+		version, ok := toUint(document[versionField])
+		if !ok {
+			continue
+		}
+		applied[version] = toTime(document[appliedAtField])
+	}
+	return applied, nil
+}
+
+// lock inserts the lock document. If it exists and it is expired, it is replaced
+// It returns the expiration of the lock and an error
+func (migrator *Migrator) lock() (time.Time, error) {
+	now := migrator.now().UTC()
+	lockDocument := map[string]interface{}{
+		idField:        lockID,
+		kindField:      kindLock,
+		ownerField:     migrator.config.Owner,
+		expiresAtField: now.Add(migrator.config.LockTTL),
+	}
+	_, err := migrator.database.InsertOne(migrator.config.Table, migrator.config.Timeout, lockDocument)
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	if !errors.As(err, &alreadyExistError) {
+		return now.Add(migrator.config.LockTTL), err
+	}
+
+	current, err := migrator.database.FindOne(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{idField: lockID})
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiresAt := toTime(current[expiresAtField])
+	if now.Before(expiresAt) {
+		return time.Time{}, &libraryErrors.AlreadyExistError{Message: fmt.Sprintf(lockedMessage, current[ownerField], expiresAt)}
+	}
+	err = migrator.database.DeleteOne(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{idField: lockID, ownerField: current[ownerField]})
+	if err != nil {
+		return time.Time{}, err
+	}
+	_, err = migrator.database.InsertOne(migrator.config.Table, migrator.config.Timeout, lockDocument)
+	return now.Add(migrator.config.LockTTL), err
+}
+
+// renew extends the lock of the Migrator before a migration, so a long run does not lose it
+// expiresAt: It is the current expiration of the lock
+// It returns the new expiration and an AlreadyExistError if the lock has expired or it has been taken by another run
+func (migrator *Migrator) renew(expiresAt time.Time) (time.Time, error) {
+	now := migrator.now().UTC()
+	if !now.Before(expiresAt) {
+		return expiresAt, &libraryErrors.AlreadyExistError{Message: fmt.Sprintf(lockLostMessage, migrator.config.Owner)}
+	}
+	renewed := now.Add(migrator.config.LockTTL)
+	_, err := migrator.database.UpdateOne(migrator.config.Table, migrator.config.Timeout,
+		map[string]interface{}{idField: lockID, ownerField: migrator.config.Owner}, map[string]interface{}{expiresAtField: renewed})
+	var notExistError *libraryErrors.NotExistError
+	if errors.As(err, &notExistError) {
+		return expiresAt, &libraryErrors.AlreadyExistError{Message: fmt.Sprintf(lockLostMessage, migrator.config.Owner)}
+	}
+	if err != nil {
+		return expiresAt, err
+	}
+	return renewed, nil
+}
+
+// unlock removes the lock document of the Migrator
+func (migrator *Migrator) unlock() {
+	_ = migrator.database.DeleteOne(migrator.config.Table, migrator.config.Timeout, map[string]interface{}{idField: lockID, ownerField: migrator.config.Owner})
+}
+
+// migrationID returns the _id of the record of a version
+func migrationID(version uint64) string {
+	return fmt.Sprintf("%s_%d", kindMigration, version)
+}
+
+// toUint converts the numbers returned by the database to uint64
+func toUint(value interface{}) (uint64, bool) {
+	switch number := value.(type) {
+	case int:
+		return uint64(number), number > 0
+	case int32:
+		return uint64(number), number > 0
+	case int64:
+		return uint64(number), number > 0
+	case uint64:
+		return number, number > 0
+	case float64:
+		return uint64(number), number > 0
+	default:
+		return 0, false
+	}
+}
+
+// toTime converts the dates returned by the database to time.Time
+func toTime(value interface{}) time.Time {
+	switch date := value.(type) {
+	case time.Time:
+		return date
+	case interface{ Time() time.Time }:
+		return date.Time()
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,304 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const ownerTest = "test"
+
+// initializeMock returns a mock that keeps the documents of the migrations table in memory, with a unique _id
+func initializeMock() (*database.DatabaseInterfaceMock, map[string]map[string]interface{}) {
+	documents := make(map[string]map[string]interface{})
+	var mutex sync.Mutex
+	matches := func(document, filter map[string]interface{}) bool {
+		for key, value := range filter {
+			if document[key] != value {
+				return false
+			}
+		}
+		return true
+	}
+	return &database.DatabaseInterfaceMock{
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			id := data[idField].(string)
+			if _, found := documents[id]; found {
+				return nil, &libraryErrors.AlreadyExistError{Message: id}
+			}
+			documents[id] = data
+			return data, nil
+		},
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, document := range documents {
+				if matches(document, filter) {
+					return document, nil
+				}
+			}
+			return nil, &libraryErrors.NotExistError{}
+		},
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			var results []map[string]interface{}
+			for _, document := range documents {
+				if matches(document, filter) {
+					results = append(results, document)
+				}
+			}
+			if len(results) == 0 {
+				return nil, &libraryErrors.NotExistError{}
+			}
+			return results, nil
+		},
+		UpdateOneFunc: func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, document := range documents {
+				if matches(document, filter) {
+					for key, value := range newData.(map[string]interface{}) {
+						document[key] = value
+					}
+					return document, nil
+				}
+			}
+			return nil, &libraryErrors.NotExistError{}
+		},
+		DeleteOneFunc: func(table string, timeout int64, filter map[string]interface{}) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for id, document := range documents {
+				if matches(document, filter) {
+					delete(documents, id)
+					return nil
+				}
+			}
+			return &libraryErrors.NotExistError{}
+		},
+	}, documents
+}
+
+func migrationsTest(calls *[]string) []Migration {
+	step := func(name string) func(ctx context.Context, db database.DatabaseInterface) error {
+		return func(ctx context.Context, db database.DatabaseInterface) error {
+			*calls = append(*calls, name)
+			return nil
+		}
+	}
+	return []Migration{
+		{Version: 2, Name: "second", Up: step("up2"), Down: step("down2")},
+		{Version: 1, Name: "first", Up: step("up1"), Down: step("down1")},
+		{Version: 3, Name: "third", Up: step("up3"), Down: step("down3")},
+	}
+}
+
+func versions(migrations []Migration) []uint64 {
+	result := make([]uint64, len(migrations))
+	for i, migration := range migrations {
+		result[i] = migration.Version
+	}
+	return result
+}
+
+func TestCreateMigratorFailed(t *testing.T) {
+	mock, _ := initializeMock()
+	up := func(ctx context.Context, db database.DatabaseInterface) error { return nil }
+	var inputError *libraryErrors.InputError
+
+	_, err := CreateMigrator(nil, nil, Config{})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = CreateMigrator(mock, nil, Config{Timeout: -1})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = CreateMigrator(mock, nil, Config{LockTTL: -time.Second})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = CreateMigrator(mock, []Migration{{Version: 0, Up: up}}, Config{})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = CreateMigrator(mock, []Migration{{Version: 1, Up: up}, {Version: 1, Up: up}}, Config{})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = CreateMigrator(mock, []Migration{{Version: 1}}, Config{})
+	assert.ErrorAs(t, err, &inputError)
+}
+
+func TestUpDownSuccess(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{Owner: ownerTest})
+	assert.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, versions(applied))
+	applied, err = migrator.Up(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3}, versions(applied))
+	assert.Len(t, documents, 3)
+
+	statuses, err := migrator.Status()
+	assert.NoError(t, err)
+	for _, status := range statuses {
+		assert.True(t, status.Applied)
+		assert.False(t, status.AppliedAt.IsZero())
+	}
+
+	rolledBack, err := migrator.Down(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3, 2}, versions(rolledBack))
+	assert.Equal(t, []string{"up1", "up2", "up3", "down3", "down2"}, calls)
+	assert.Len(t, documents, 1)
+
+	statuses, err = migrator.Status()
+	assert.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestDryRunSuccess(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{DryRun: true})
+	assert.NoError(t, err)
+
+	pending, err := migrator.Up(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, versions(pending))
+	assert.Empty(t, calls)
+	assert.Empty(t, documents)
+}
+
+func TestUpFailed(t *testing.T) {
+	mock, documents := initializeMock()
+	failure := errors.New("failure")
+	up := func(ctx context.Context, db database.DatabaseInterface) error { return nil }
+	migrator, err := CreateMigrator(mock, []Migration{
+		{Version: 1, Up: up},
+		{Version: 2, Up: func(ctx context.Context, db database.DatabaseInterface) error { return failure }},
+		{Version: 3, Up: up},
+	}, Config{})
+	assert.NoError(t, err)
+
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []uint64{1}, versions(applied))
+	assert.Len(t, documents, 1)
+}
+
+func TestDownFailedMissingDown(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrations := migrationsTest(&calls)
+	migrations[0].Down = nil
+	migrator, err := CreateMigrator(mock, migrations, Config{})
+	assert.NoError(t, err)
+	_, err = migrator.Up(context.Background(), 0)
+	assert.NoError(t, err)
+
+	var inputError *libraryErrors.InputError
+	rolledBack, err := migrator.Down(context.Background(), 0)
+	assert.ErrorAs(t, err, &inputError)
+	assert.Empty(t, rolledBack)
+	assert.Len(t, documents, 3)
+}
+
+func TestLockFailed(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{Owner: ownerTest})
+	assert.NoError(t, err)
+
+	documents[lockID] = map[string]interface{}{idField: lockID, ownerField: "other", expiresAtField: time.Now().Add(time.Hour)}
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	_, err = migrator.Up(context.Background(), 0)
+	assert.ErrorAs(t, err, &alreadyExistError)
+	assert.Empty(t, calls)
+	assert.Equal(t, "other", documents[lockID][ownerField])
+}
+
+func TestLockSuccessExpired(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{Owner: ownerTest})
+	assert.NoError(t, err)
+
+	documents[lockID] = map[string]interface{}{idField: lockID, ownerField: "other", expiresAtField: time.Now().Add(-time.Minute)}
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 3)
+	_, found := documents[lockID]
+	assert.False(t, found)
+}
+
+func TestLockRenewed(t *testing.T) {
+	mock, documents := initializeMock()
+	migrator, err := CreateMigrator(mock, nil, Config{Owner: ownerTest, LockTTL: time.Minute})
+	assert.NoError(t, err)
+	now := time.Now()
+	var expiresAt []time.Time
+	migrator.migrations = []Migration{
+		{Version: 1, Name: "first", Up: func(ctx context.Context, db database.DatabaseInterface) error {
+			expiresAt = append(expiresAt, documents[lockID][expiresAtField].(time.Time))
+			now = now.Add(50 * time.Second)
+			return nil
+		}},
+		{Version: 2, Name: "second", Up: func(ctx context.Context, db database.DatabaseInterface) error {
+			expiresAt = append(expiresAt, documents[lockID][expiresAtField].(time.Time))
+			return nil
+		}},
+	}
+	migrator.now = func() time.Time { return now }
+
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+	if assert.Len(t, expiresAt, 2) {
+		assert.Equal(t, 50*time.Second, expiresAt[1].Sub(expiresAt[0]))
+	}
+}
+
+func TestLockFailedTakenOver(t *testing.T) {
+	mock, documents := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{Owner: ownerTest})
+	assert.NoError(t, err)
+	migrator.migrations[0].Up = func(ctx context.Context, db database.DatabaseInterface) error {
+		calls = append(calls, "up1")
+		documents[lockID] = map[string]interface{}{idField: lockID, ownerField: "other", expiresAtField: time.Now().Add(time.Hour)}
+		return nil
+	}
+
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.ErrorAs(t, err, &alreadyExistError)
+	assert.Equal(t, []uint64{1}, versions(applied))
+	assert.Equal(t, []string{"up1"}, calls)
+	assert.Equal(t, "other", documents[lockID][ownerField])
+}
+
+func TestLockFailedExpired(t *testing.T) {
+	mock, _ := initializeMock()
+	var calls []string
+	migrator, err := CreateMigrator(mock, migrationsTest(&calls), Config{Owner: ownerTest, LockTTL: time.Minute})
+	assert.NoError(t, err)
+	now := time.Now()
+	migrator.now = func() time.Time { return now }
+	migrator.migrations[0].Up = func(ctx context.Context, db database.DatabaseInterface) error {
+		calls = append(calls, "up1")
+		now = now.Add(2 * time.Minute)
+		return nil
+	}
+
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	applied, err := migrator.Up(context.Background(), 0)
+	assert.ErrorAs(t, err, &alreadyExistError)
+	assert.Equal(t, []uint64{1}, versions(applied))
+	assert.Equal(t, []string{"up1"}, calls)
+}
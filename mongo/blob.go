@@ -0,0 +1,328 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WithBlobBucket defines the name of the GridFS bucket of the blob functions. By default, it is fs
+func WithBlobBucket(bucket string) Option {
+	return func(config *Config) {
+		config.BlobBucket = bucket
+	}
+}
+
+// blobFile is the structure of the documents of the files collection of GridFS
+type blobFile struct {
+	ID         interface{}            `bson:"_id"`
+	Length     int64                  `bson:"length"`
+	UploadDate time.Time              `bson:"uploadDate"`
+	Name       string                 `bson:"filename"`
+	Metadata   map[string]interface{} `bson:"metadata"`
+}
+
+// rangeReader is the reader returned by OpenRange. It releases the operation when it is closed or when its
+// context is done
+type rangeReader struct {
+	io.Reader
+	ctx     context.Context
+	stream  *gridfs.DownloadStream
+	release func()
+	stop    func() bool
+	mutex   sync.Mutex
+	once    sync.Once
+}
+
+// info converts the document of the files collection to the description of the object
+func (file blobFile) info() database.BlobInfo {
+	return database.BlobInfo{
+		ID:         blobID(file.ID),
+		Name:       file.Name,
+		Size:       file.Length,
+		UploadDate: file.UploadDate,
+		Metadata:   file.Metadata,
+	}
+}
+
+// Upload is the function inside the Manager to save the content of a reader in GridFS, without the size limit of
+// the documents. It follows the database.BlobStore interface
+// ctx: It is the context of the upload. If it is cancelled, the chunks already written are removed
+// name: It is the name of the object
+// source: It is the reader with the content
+// metadata: It is the extra data saved with the object
+// It returns the description of the object, with the upload date saved by GridFS, and an error
+func (manager *Manager) Upload(ctx context.Context, name string, source io.Reader, metadata map[string]interface{}) (database.BlobInfo, error) {
+	bucket, ctx, release, err := manager.getBucket(ctx)
+	if err != nil {
+		return database.BlobInfo{}, err
+	}
+	defer release()
+
+	opts := options.GridFSUpload()
+	if metadata != nil {
+		opts.SetMetadata(metadata)
+	}
+	stream, err := bucket.OpenUploadStream(name, opts)
+	if err != nil {
+		return database.BlobInfo{}, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetWriteDeadline(deadline)
+	}
+	if _, err := copyContext(ctx, stream, source); err != nil {
+		_ = stream.Abort()
+		return database.BlobInfo{}, err
+	}
+	if err := stream.Close(); err != nil {
+		return database.BlobInfo{}, err
+	}
+
+	files, err := findBlobs(ctx, bucket, map[string]interface{}{"_id": stream.FileID})
+	if err != nil {
+		return database.BlobInfo{}, err
+	}
+	if len(files) == 0 {
+		return database.BlobInfo{}, &libraryErrors.NotExistError{Message: blobNotFoundMessage}
+	}
+	return files[0].info(), nil
+}
+
+// Download is the function inside the Manager to write the content of an object of GridFS to a writer
+// ctx: It is the context of the download
+// id: It is the identifier of the object
+// destination: It is the writer for the content
+// It returns the number of bytes written and an error. It is a NotExistError if the object does not exist
+func (manager *Manager) Download(ctx context.Context, id string, destination io.Writer) (int64, error) {
+	reader, err := manager.OpenRange(ctx, id, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	return copyContext(ctx, destination, reader)
+}
+
+// Delete is the function inside the Manager to remove an object of GridFS and its chunks
+// ctx: It is the context of the deletion
+// id: It is the identifier of the object
+// It returns an error. It is a NotExistError if the object does not exist
+func (manager *Manager) Delete(ctx context.Context, id string) error {
+	fileID, err := parseBlobID(id)
+	if err != nil {
+		return err
+	}
+	bucket, ctx, release, err := manager.getBucket(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return blobError(bucket.DeleteContext(ctx, fileID))
+}
+
+// List is the function inside the Manager to get the objects of GridFS that match the filter
+// ctx: It is the context of the search
+// filter: It is the filter on the fields of the files collection (filename, length, uploadDate, metadata.<key>)
+// It returns the description of the objects and an error
+func (manager *Manager) List(ctx context.Context, filter map[string]interface{}) ([]database.BlobInfo, error) {
+	bucket, ctx, release, err := manager.getBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if filter == nil {
+		filter = map[string]interface{}{}
+	}
+
+	files, err := findBlobs(ctx, bucket, filter)
+	if err != nil {
+		return nil, err
+	}
+	var blobs []database.BlobInfo
+	for _, file := range files {
+		blobs = append(blobs, file.info())
+	}
+	return blobs, nil
+}
+
+// OpenRange is the function inside the Manager to read a part of an object of GridFS, for example, to answer the
+// HTTP range requests. The operation is in flight until the reader is closed
+// ctx: It is the context of the reads. Its deadline applies to all the reads and, when it is cancelled, the reader
+// is closed and the next reads return its error
+// id: It is the identifier of the object
+// offset: It is the first byte to read
+// length: It is the number of bytes to read. If it is negative, the reader returns the rest of the object
+// It returns the reader and an error. It is a NotExistError if the object does not exist
+func (manager *Manager) OpenRange(ctx context.Context, id string, offset, length int64) (io.ReadCloser, error) {
+	fileID, err := parseBlobID(id)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(blobOffsetMessage, offset)}
+	}
+	bucket, ctx, release, err := manager.getBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(fileID)
+	if err != nil {
+		release()
+		return nil, blobError(err)
+	}
+	if err := ctx.Err(); err != nil {
+		_ = stream.Close()
+		release()
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetReadDeadline(deadline)
+	}
+	if _, err := stream.Skip(offset); err != nil {
+		_ = stream.Close()
+		release()
+		return nil, err
+	}
+
+	var limited io.Reader = stream
+	if length >= 0 {
+		limited = io.LimitReader(stream, length)
+	}
+	return newRangeReader(ctx, stream, limited, release), nil
+}
+
+// newRangeReader creates the reader of the stream, which is closed when the context is done
+func newRangeReader(ctx context.Context, stream *gridfs.DownloadStream, limited io.Reader, release func()) *rangeReader {
+	reader := &rangeReader{Reader: limited, ctx: ctx, stream: stream, release: release}
+	reader.stop = context.AfterFunc(ctx, func() {
+		_ = reader.Close()
+	})
+	return reader
+}
+
+// Read reads the object until the context of the reader is done
+func (reader *rangeReader) Read(buffer []byte) (int, error) {
+	reader.mutex.Lock()
+	defer reader.mutex.Unlock()
+	if err := reader.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return reader.Reader.Read(buffer)
+}
+
+// Close closes the download stream and releases the operation
+func (reader *rangeReader) Close() error {
+	reader.stop()
+	reader.mutex.Lock()
+	err := reader.stream.Close()
+	reader.mutex.Unlock()
+	reader.once.Do(reader.release)
+	if errors.Is(err, gridfs.ErrStreamClosed) {
+		return nil
+	}
+	return err
+}
+
+// getBucket returns the GridFS bucket of the Manager and a context that is also cancelled by Shutdown. The
+// operation is counted as in-flight until release is called
+func (manager *Manager) getBucket(ctx context.Context) (*gridfs.Bucket, context.Context, func(), error) {
+	_, mongoDatabase, operations, err := manager.acquire()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	root := manager.owner()
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(operations, cancel)
+	release := func() {
+		stop()
+		cancel()
+		root.release()
+	}
+
+	opts := options.GridFSBucket()
+	if manager.config.BlobBucket != "" {
+		opts.SetName(manager.config.BlobBucket)
+	}
+	bucket, err := gridfs.NewBucket(mongoDatabase, opts)
+	if err != nil {
+		release()
+		return nil, nil, nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = bucket.SetReadDeadline(deadline)
+		_ = bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, ctx, release, nil
+}
+
+// findBlobs returns the documents of the files collection of the bucket that match the filter
+func findBlobs(ctx context.Context, bucket *gridfs.Bucket, filter interface{}) ([]blobFile, error) {
+	cursor, err := bucket.FindContext(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var files []blobFile
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// copyContext copies the reader to the writer until the end of the reader or until the context is done
+func copyContext(ctx context.Context, destination io.Writer, source io.Reader) (int64, error) {
+	buffer := make([]byte, blobBufferSize)
+	var written int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		read, err := source.Read(buffer)
+		if read > 0 {
+			n, writeErr := destination.Write(buffer[:read])
+			written += int64(n)
+			if writeErr != nil {
+				return written, writeErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
+
+// parseBlobID converts the identifier returned by Upload to the _id of GridFS
+func parseBlobID(id string) (primitive.ObjectID, error) {
+	fileID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, &libraryErrors.InputError{Message: fmt.Sprintf(blobIDMessage, id)}
+	}
+	return fileID, nil
+}
+
+// blobID converts the _id of GridFS to the identifier of the BlobInfo
+func blobID(id interface{}) string {
+	if objectID, ok := id.(primitive.ObjectID); ok {
+		return objectID.Hex()
+	}
+	return fmt.Sprint(id)
+}
+
+// blobError converts the errors of GridFS to the errors of the library
+func blobError(err error) error {
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return &libraryErrors.NotExistError{Message: blobNotFoundMessage}
+	}
+	return err
+}
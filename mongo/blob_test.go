@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+func TestBlobSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	ctx := context.Background()
+	content := strings.Repeat("0123456789", 100000)
+
+	info, err := mongoManager.Upload(ctx, "test.txt", strings.NewReader(content), map[string]interface{}{"owner": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.False(t, info.UploadDate.IsZero())
+
+	var downloaded bytes.Buffer
+	size, err := mongoManager.Download(ctx, info.ID, &downloaded)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, content, downloaded.String())
+
+	reader, err := mongoManager.OpenRange(ctx, info.ID, 300000, 15)
+	assert.NoError(t, err)
+	part, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, content[300000:300015], string(part))
+	assert.NoError(t, reader.Close())
+
+	blobs, err := mongoManager.List(ctx, map[string]interface{}{"metadata.owner": "test"})
+	assert.NoError(t, err)
+	assert.Len(t, blobs, 1)
+	assert.Equal(t, info.ID, blobs[0].ID)
+	assert.Equal(t, "test.txt", blobs[0].Name)
+	assert.Equal(t, info.UploadDate, blobs[0].UploadDate)
+	assert.Equal(t, "test", blobs[0].Metadata["owner"])
+
+	assert.NoError(t, mongoManager.Delete(ctx, info.ID))
+	err = mongoManager.Delete(ctx, info.ID)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.Download(ctx, info.ID, io.Discard)
+	assert.ErrorAs(t, err, &myErr)
+
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestBlobFailedInvalidInput(t *testing.T) {
+	mongoManager := new(Manager)
+	var myErr *libraryErrors.InputError
+
+	_, err := mongoManager.OpenRange(context.Background(), "invalid", 0, -1)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.OpenRange(context.Background(), "000000000000000000000000", -1, -1)
+	assert.ErrorAs(t, err, &myErr)
+	err = mongoManager.Delete(context.Background(), "invalid")
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestBlobFailedClientNotCreated(t *testing.T) {
+	mongoManager := new(Manager)
+	var myErr *libraryErrors.ClientError
+
+	_, err := mongoManager.Upload(context.Background(), "test.txt", strings.NewReader("test"), nil)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.List(context.Background(), nil)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.Download(context.Background(), "000000000000000000000000", io.Discard)
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestCopyContext(t *testing.T) {
+	var destination bytes.Buffer
+	written, err := copyContext(context.Background(), &destination, strings.NewReader("test"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), written)
+	assert.Equal(t, "test", destination.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = copyContext(ctx, &destination, strings.NewReader("test"))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRangeReaderClosedOnCancel(t *testing.T) {
+	released := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := newRangeReader(ctx, new(gridfs.DownloadStream), strings.NewReader("test"), func() { close(released) })
+
+	part := make([]byte, 2)
+	read, err := reader.Read(part)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, read)
+
+	cancel()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("the reader was not released")
+	}
+	_, err = reader.Read(part)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, reader.Close())
+}
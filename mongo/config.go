@@ -0,0 +1,372 @@
+package mongo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Config is the structure with the settings of the client created in ConnectDb. The settings that are
+// not defined keep the value of the URI or the default value of the driver
+// AppName: It is the name of the application sent to the MongoDB
+// MinPoolSize: It is the minimum number of connections of the pool
+// MaxPoolSize: It is the maximum number of connections of the pool. 0 means the default of the driver
+// MaxConnIdleTime: It is the maximum time that a connection stays idle in the pool
+// ReadPreference: It is the read preference (primary, primaryPreferred, secondary, secondaryPreferred or nearest)
+// WriteConcern: It is the write concern: "majority" or the number of nodes that acknowledge the writes
+// Journal: It defines if the writes must be written in the journal before being acknowledged
+// Compressors: They are the compressors for the communication (snappy, zlib or zstd)
+// TLSCAFile: It is the file with the certificate authorities to validate the server
+// TLSCertificateKeyFile: It is the file with the certificate and the private key of the client
+// TLSInsecure: It disables the validation of the certificate of the server
+// AuthMechanism: It is the authentication mechanism (SCRAM-SHA-1, SCRAM-SHA-256, MONGODB-X509...)
+// AuthSource: It is the database used for the authentication
+// Username: It is the user for the authentication
+// Password: It is the password for the authentication
+// ServerAPIVersion: It is the version of the Stable API. If it is empty, version 1 is used
+// DisableServerAPI: It disables the Stable API
+// PoolMonitor: It is the monitor of the connection pool
+// Logger: It is the logger of the Manager (see SetLogger)
+// SlowQueryThreshold: It is the threshold of the slow operations (see SetSlowQueryThreshold)
+// Supervision: It enables the supervision and the automatic reconnection of the connection (see WithSupervision)
+// TenantDatabase: It maps a tenant to the name of its DB in ForTenant (see WithTenantDatabase)
+// BlobBucket: It is the name of the GridFS bucket of the blob functions (see WithBlobBucket)
+// PaginationKey: It is the key that signs the tokens of Paginate (see WithPaginationKey)
+// DrainTimeout: It is the time that DisconnectDb waits for the in-flight operations. If it is 0, the timeout of the
+// connection is used
+type Config struct {
+	AppName               string
+	MinPoolSize           uint64
+	MaxPoolSize           uint64
+	MaxConnIdleTime       time.Duration
+	ReadPreference        string
+	WriteConcern          string
+	Journal               *bool
+	Compressors           []string
+	TLSCAFile             string
+	TLSCertificateKeyFile string
+	TLSInsecure           bool
+	AuthMechanism         string
+	AuthSource            string
+	Username              string
+	Password              string
+	ServerAPIVersion      string
+	DisableServerAPI      bool
+	PoolMonitor           *event.PoolMonitor
+	Logger                *slog.Logger
+	SlowQueryThreshold    time.Duration
+	Supervision           *SupervisionConfig
+	DrainTimeout          time.Duration
+	TenantDatabase        func(tenant string) string
+	BlobBucket            string
+	PaginationKey         []byte
+}
+
+// Option is a function to modify the Config of the Manager
+type Option func(config *Config)
+
+// WithConfig replaces the whole Config
+func WithConfig(newConfig Config) Option {
+	return func(config *Config) {
+		*config = newConfig
+	}
+}
+
+// WithAppName defines the name of the application
+func WithAppName(appName string) Option {
+	return func(config *Config) {
+		config.AppName = appName
+	}
+}
+
+// WithPoolSize defines the minimum and the maximum number of connections of the pool
+func WithPoolSize(minPoolSize, maxPoolSize uint64) Option {
+	return func(config *Config) {
+		config.MinPoolSize = minPoolSize
+		config.MaxPoolSize = maxPoolSize
+	}
+}
+
+// WithMaxConnIdleTime defines the maximum time that a connection stays idle in the pool
+func WithMaxConnIdleTime(maxConnIdleTime time.Duration) Option {
+	return func(config *Config) {
+		config.MaxConnIdleTime = maxConnIdleTime
+	}
+}
+
+// WithReadPreference defines the read preference (primary, primaryPreferred, secondary, secondaryPreferred or nearest)
+func WithReadPreference(readPreference string) Option {
+	return func(config *Config) {
+		config.ReadPreference = readPreference
+	}
+}
+
+// WithWriteConcern defines the write concern ("majority" or the number of nodes) and if the journal is required
+func WithWriteConcern(writeConcern string, journal bool) Option {
+	return func(config *Config) {
+		config.WriteConcern = writeConcern
+		config.Journal = &journal
+	}
+}
+
+// WithCompressors defines the compressors for the communication (snappy, zlib or zstd)
+func WithCompressors(compressors ...string) Option {
+	return func(config *Config) {
+		config.Compressors = compressors
+	}
+}
+
+// WithTLSFiles defines the file with the certificate authorities and the file with the certificate and key of the client.
+// Any of them may be empty
+func WithTLSFiles(caFile, certificateKeyFile string) Option {
+	return func(config *Config) {
+		config.TLSCAFile = caFile
+		config.TLSCertificateKeyFile = certificateKeyFile
+	}
+}
+
+// WithAuth defines the authentication mechanism, the database for the authentication and the credentials
+func WithAuth(mechanism, source, username, password string) Option {
+	return func(config *Config) {
+		config.AuthMechanism = mechanism
+		config.AuthSource = source
+		config.Username = username
+		config.Password = password
+	}
+}
+
+// WithServerAPIVersion defines the version of the Stable API
+func WithServerAPIVersion(version string) Option {
+	return func(config *Config) {
+		config.ServerAPIVersion = version
+	}
+}
+
+// WithoutServerAPI disables the Stable API
+func WithoutServerAPI() Option {
+	return func(config *Config) {
+		config.DisableServerAPI = true
+	}
+}
+
+// WithPoolMonitor defines the monitor of the connection pool
+func WithPoolMonitor(poolMonitor *event.PoolMonitor) Option {
+	return func(config *Config) {
+		config.PoolMonitor = poolMonitor
+	}
+}
+
+// WithLogger defines the logger of the Manager
+func WithLogger(logger *slog.Logger) Option {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithSlowQueryThreshold defines the threshold of the slow operations
+func WithSlowQueryThreshold(threshold time.Duration) Option {
+	return func(config *Config) {
+		config.SlowQueryThreshold = threshold
+	}
+}
+
+// WithDrainTimeout defines the time that DisconnectDb waits for the in-flight operations before closing the client
+func WithDrainTimeout(drainTimeout time.Duration) Option {
+	return func(config *Config) {
+		config.DrainTimeout = drainTimeout
+	}
+}
+
+// Configure is the function inside the Manager to apply the options to its Config. It must be called before ConnectDb
+// opts: They are the options to apply
+// It returns an InputError if the resulting Config is not valid. In that case, the Config is not modified
+func (manager *Manager) Configure(opts ...Option) error {
+	config := manager.config
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	manager.config = config
+	if config.Logger != nil {
+		manager.logger = config.Logger
+	}
+	if config.SlowQueryThreshold > 0 {
+		manager.slowQueryThreshold = config.SlowQueryThreshold
+	}
+	return nil
+}
+
+// Validate checks that the values of the Config are valid
+// It returns an InputError with the first invalid value
+func (config Config) Validate() error {
+	if config.MaxPoolSize != 0 && config.MinPoolSize > config.MaxPoolSize {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(poolSizeMessage, config.MinPoolSize, config.MaxPoolSize)}
+	}
+	if config.MaxConnIdleTime < 0 {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(maxConnIdleTimeMessage, config.MaxConnIdleTime)}
+	}
+	if config.ReadPreference != "" {
+		if _, err := readpref.ModeFromString(config.ReadPreference); err != nil {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(readPreferenceMessage, config.ReadPreference)}
+		}
+	}
+	if config.WriteConcern != "" && config.WriteConcern != writeConcernMajority {
+		if nodes, err := strconv.Atoi(config.WriteConcern); err != nil || nodes < 0 {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(writeConcernMessage, config.WriteConcern)}
+		}
+	}
+	for _, compressor := range config.Compressors {
+		if !validCompressors[compressor] {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(compressorMessage, compressor)}
+		}
+	}
+	for _, file := range []string{config.TLSCAFile, config.TLSCertificateKeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(tlsFileMessage, file)}
+		}
+	}
+	if config.AuthMechanism != "" && !validAuthMechanisms[config.AuthMechanism] {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(authMechanismMessage, config.AuthMechanism)}
+	}
+	if config.ServerAPIVersion != "" && config.ServerAPIVersion != string(options.ServerAPIVersion1) {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(serverAPIVersionMessage, config.ServerAPIVersion)}
+	}
+	if config.SlowQueryThreshold < 0 {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(slowQueryThresholdMessage, config.SlowQueryThreshold)}
+	}
+	if supervision := config.Supervision; supervision != nil &&
+		(supervision.CheckInterval < 0 || supervision.InitialBackoff < 0 || supervision.MaxBackoff < 0) {
+		return &libraryErrors.InputError{Message: supervisionMessage}
+	}
+	if config.DrainTimeout < 0 {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(drainTimeoutConfigMessage, config.DrainTimeout)}
+	}
+	return nil
+}
+
+// clientOptions creates the options of the driver client from the URI and the Config. The authentication of the
+// Config is merged onto the credential of the URI
+func (config Config) clientOptions(dbURI string) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(dbURI)
+	if !config.DisableServerAPI {
+		opts.SetServerAPIOptions(options.ServerAPI(options.ServerAPIVersion1))
+	}
+	if config.AppName != "" {
+		opts.SetAppName(config.AppName)
+	}
+	if config.MinPoolSize != 0 {
+		opts.SetMinPoolSize(config.MinPoolSize)
+	}
+	if config.MaxPoolSize != 0 {
+		opts.SetMaxPoolSize(config.MaxPoolSize)
+	}
+	if config.MaxConnIdleTime != 0 {
+		opts.SetMaxConnIdleTime(config.MaxConnIdleTime)
+	}
+	if config.ReadPreference != "" {
+		mode, _ := readpref.ModeFromString(config.ReadPreference)
+		readPreference, err := readpref.New(mode)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(readPreferenceMessage, config.ReadPreference)}
+		}
+		opts.SetReadPreference(readPreference)
+	}
+	if config.WriteConcern != "" || config.Journal != nil {
+		opts.SetWriteConcern(config.writeConcern())
+	}
+	if len(config.Compressors) > 0 {
+		opts.SetCompressors(config.Compressors)
+	}
+	if config.TLSCAFile != "" || config.TLSCertificateKeyFile != "" || config.TLSInsecure {
+		tlsConfig, err := config.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+	if opts.Auth != nil || config.AuthMechanism != "" || config.Username != "" {
+		opts.SetAuth(config.credential(opts.Auth))
+	}
+	if config.PoolMonitor != nil {
+		opts.SetPoolMonitor(config.PoolMonitor)
+	}
+	return opts, nil
+}
+
+// credential merges the authentication of the Config onto the credential parsed from the URI. Only the fields
+// defined in the Config replace the ones of the URI
+func (config Config) credential(uriCredential *options.Credential) options.Credential {
+	var credential options.Credential
+	if uriCredential != nil {
+		credential = *uriCredential
+	}
+	if config.AuthMechanism != "" {
+		credential.AuthMechanism = config.AuthMechanism
+	}
+	if config.AuthSource != "" {
+		credential.AuthSource = config.AuthSource
+	}
+	if config.Username != "" {
+		credential.Username = config.Username
+	}
+	if config.Password != "" {
+		credential.Password = config.Password
+		credential.PasswordSet = true
+	}
+	return credential
+}
+
+// writeConcern creates the write concern of the driver from the Config
+func (config Config) writeConcern() *writeconcern.WriteConcern {
+	concern := &writeconcern.WriteConcern{Journal: config.Journal}
+	if config.WriteConcern == writeConcernMajority {
+		concern.W = writeConcernMajority
+	} else if config.WriteConcern != "" {
+		nodes, _ := strconv.Atoi(config.WriteConcern)
+		concern.W = nodes
+	}
+	return concern
+}
+
+// tlsConfig creates the TLS configuration from the files of the Config
+func (config Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: config.TLSInsecure} //nolint:gosec
+	if config.TLSCAFile != "" {
+		content, err := os.ReadFile(config.TLSCAFile)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(tlsFileMessage, config.TLSCAFile)}
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(tlsFileMessage, config.TLSCAFile)}
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.TLSCertificateKeyFile != "" {
+		content, err := os.ReadFile(config.TLSCertificateKeyFile)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(tlsFileMessage, config.TLSCertificateKeyFile)}
+		}
+		certificate, err := tls.X509KeyPair(content, content)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(tlsFileMessage, config.TLSCertificateKeyFile)}
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
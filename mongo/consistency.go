@@ -0,0 +1,80 @@
+package mongo
+
+import (
+	"fmt"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// WithConsistency is the function inside the Manager to create a copy of the Manager that shares the client and
+// applies the read preference, read concern and write concern to its operations. It must be called after ConnectDb.
+// The writes read back their documents from the primary, whatever the read preference. ConnectDb, DisconnectDb and
+// Shutdown do not change the shared client
+// consistency: It is the consistency of the operations. The empty fields keep the values of the Manager, which are
+// the ones of the client unless the Manager is also a copy created by WithConsistency
+// It returns the copy of the Manager and an InputError if some value is not valid
+func (manager *Manager) WithConsistency(consistency database.Consistency) (database.DatabaseInterface, error) {
+	collectionOptions := options.Collection()
+	if manager.collectionOptions != nil {
+		collectionOptions.ReadPreference = manager.collectionOptions.ReadPreference
+		collectionOptions.ReadConcern = manager.collectionOptions.ReadConcern
+		collectionOptions.WriteConcern = manager.collectionOptions.WriteConcern
+	}
+	if consistency.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(string(consistency.ReadPreference))
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(readPreferenceMessage, consistency.ReadPreference)}
+		}
+		readPreference, err := readpref.New(mode)
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(readPreferenceMessage, consistency.ReadPreference)}
+		}
+		collectionOptions.SetReadPreference(readPreference)
+	}
+	if consistency.ReadConcern != "" {
+		if !validReadConcerns[consistency.ReadConcern] {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(readConcernMessage, consistency.ReadConcern)}
+		}
+		collectionOptions.SetReadConcern(&readconcern.ReadConcern{Level: string(consistency.ReadConcern)})
+	}
+	if consistency.WriteConcern != "" {
+		writeConcern, found := writeConcerns[consistency.WriteConcern]
+		if !found {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(writeConcernMessage, consistency.WriteConcern)}
+		}
+		collectionOptions.SetWriteConcern(writeConcern)
+	}
+
+	return &Manager{
+		root:               manager.owner(),
+		databaseName:       manager.databaseName,
+		logger:             manager.logger,
+		slowQueryThreshold: manager.slowQueryThreshold,
+		redaction:          manager.redaction,
+		redactedKeys:       manager.redactedKeys,
+		config:             manager.config,
+		collectionOptions:  collectionOptions,
+	}, nil
+}
+
+var validReadConcerns = map[database.ReadConcern]bool{
+	database.ReadConcernLocal:        true,
+	database.ReadConcernAvailable:    true,
+	database.ReadConcernMajority:     true,
+	database.ReadConcernLinearizable: true,
+	database.ReadConcernSnapshot:     true,
+}
+
+var writeConcerns = map[database.WriteConcern]*writeconcern.WriteConcern{
+	database.WriteUnacknowledged:    writeconcern.Unacknowledged(),
+	database.WriteAcknowledged:      writeconcern.W1(),
+	database.WriteMajority:          writeconcern.Majority(),
+	database.WriteMajorityJournaled: {W: writeConcernMajority, Journal: &journaled},
+}
+
+var journaled = true
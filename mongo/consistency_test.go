@@ -0,0 +1,161 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestWithConsistencySuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	db, err := mongoManager.WithConsistency(database.Consistency{
+		ReadPreference: database.ReadPrimaryPreferred,
+		ReadConcern:    database.ReadConcernMajority,
+		WriteConcern:   database.WriteMajority,
+	})
+	assert.NoError(t, err)
+
+	insertDocument := map[string]interface{}{
+		"test": "test",
+	}
+	result, err := db.InsertOne(collectionTest, timeoutTest, insertDocument)
+	assert.NoError(t, err)
+	resultFind, err := db.FindOne(collectionTest, timeoutTest, map[string]interface{}{"_id": result["_id"]})
+	assert.NoError(t, err)
+	assert.Equal(t, result, resultFind)
+
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestWithConsistencyUnacknowledgedSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	db, err := mongoManager.WithConsistency(database.Consistency{WriteConcern: database.WriteUnacknowledged})
+	assert.NoError(t, err)
+
+	insertDocument := map[string]interface{}{"test": "test"}
+	result, err := db.InsertOne(collectionTest, timeoutTest, insertDocument)
+	assert.NoError(t, err)
+	assert.NotNil(t, result["_id"])
+	assert.Equal(t, "test", result["test"])
+	results, err := db.InsertMany(collectionTest, timeoutTest, []map[string]interface{}{insertDocument, insertDocument})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	resultUpdate, err := db.UpdateOne(collectionTest, timeoutTest, insertDocument, map[string]interface{}{"test": "test2"})
+	assert.NoError(t, err)
+	assert.Nil(t, resultUpdate)
+	resultsUpdate, err := db.UpdateMany(collectionTest, timeoutTest, map[string]interface{}{"test": "missing"}, insertDocument)
+	assert.NoError(t, err)
+	assert.Nil(t, resultsUpdate)
+	err = db.DeleteOne(collectionTest, timeoutTest, map[string]interface{}{"test": "missing"})
+	assert.NoError(t, err)
+	deleted, err := db.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestUnacknowledgedDocuments(t *testing.T) {
+	document := map[string]interface{}{"test": "test"}
+	results := unacknowledgedDocuments([]map[string]interface{}{document}, []interface{}{"id"})
+	assert.Equal(t, []map[string]interface{}{{"test": "test", "_id": "id"}}, results)
+	assert.Equal(t, map[string]interface{}{"test": "test"}, document)
+}
+
+func TestWithConsistencyOptions(t *testing.T) {
+	mongoManager := new(Manager)
+
+	db, err := mongoManager.WithConsistency(database.Consistency{
+		ReadPreference: database.ReadSecondaryPreferred,
+		ReadConcern:    database.ReadConcernLocal,
+		WriteConcern:   database.WriteMajorityJournaled,
+	})
+	assert.NoError(t, err)
+	managerCopy, ok := db.(*Manager)
+	assert.True(t, ok)
+	assert.Nil(t, mongoManager.collectionOptions)
+	assert.Equal(t, readpref.SecondaryPreferredMode, managerCopy.collectionOptions.ReadPreference.Mode())
+	assert.Equal(t, "local", managerCopy.collectionOptions.ReadConcern.Level)
+	assert.Equal(t, "majority", managerCopy.collectionOptions.WriteConcern.W)
+	assert.True(t, *managerCopy.collectionOptions.WriteConcern.Journal)
+
+	db, err = managerCopy.WithConsistency(database.Consistency{ReadConcern: database.ReadConcernMajority})
+	assert.NoError(t, err)
+	nestedCopy := db.(*Manager)
+	assert.Equal(t, readpref.SecondaryPreferredMode, nestedCopy.collectionOptions.ReadPreference.Mode())
+	assert.Equal(t, "majority", nestedCopy.collectionOptions.ReadConcern.Level)
+	assert.True(t, *nestedCopy.collectionOptions.WriteConcern.Journal)
+	assert.Equal(t, "local", managerCopy.collectionOptions.ReadConcern.Level)
+}
+
+func TestWithConsistencySharedClient(t *testing.T) {
+	mongoManager := new(Manager)
+	db, err := mongoManager.WithConsistency(database.Consistency{ReadPreference: database.ReadNearest})
+	assert.NoError(t, err)
+	var myErr *libraryErrors.ClientError
+
+	err = db.ConnectDb("mongodb://localhost:27017", dbTest, timeoutTest)
+	assert.ErrorAs(t, err, &myErr)
+	cancelled, err := db.(*Manager).Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+}
+
+func TestWithConsistencyReadBackPrimary(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	db, err := mongoManager.WithConsistency(database.Consistency{ReadPreference: database.ReadSecondaryPreferred})
+	assert.NoError(t, err)
+	result, err := db.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"test": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result["test"])
+	result, err = db.UpdateOne(collectionTest, timeoutTest, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test2", result["test"])
+	assert.NoError(t, db.DisconnectDb())
+	_, err = mongoManager.FindOne(collectionTest, timeoutTest, map[string]interface{}{"_id": result["_id"]})
+	assert.NoError(t, err)
+
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestWithConsistencyFailedInvalidValue(t *testing.T) {
+	mongoManager := new(Manager)
+	invalidConsistencies := []database.Consistency{
+		{ReadPreference: "test"},
+		{ReadConcern: "test"},
+		{WriteConcern: "test"},
+	}
+	for _, consistency := range invalidConsistencies {
+		db, err := mongoManager.WithConsistency(consistency)
+		assert.Nil(t, db)
+		var myErr *libraryErrors.InputError
+		assert.ErrorAs(t, err, &myErr)
+	}
+}
+
+func TestWithConsistencyFailedClientNotCreated(t *testing.T) {
+	mongoManager := new(Manager)
+
+	db, err := mongoManager.WithConsistency(database.Consistency{ReadPreference: database.ReadNearest})
+	assert.NoError(t, err)
+	result, err := db.FindOne(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.Nil(t, result)
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
@@ -1,8 +1,102 @@
 package mongo
 
+import "time"
+
+const (
+	clientNotConnected        = "Client is not connected"
+	shuttingDownMessage       = "Client is shutting down"
+	alreadyConnectedMessage   = "Client is already connected"
+	documentNotFoundMessage   = "Document not found"
+	mongoDB                   = "MongoDB"
+	timeoutMessage            = "Invalid timeout: %d. It must be higher than 0"
+	redactedValue             = "[REDACTED]"
+	writeConcernMajority      = "majority"
+	mongoScheme               = "mongodb"
+	mongoSRVScheme            = "mongodb+srv"
+	defaultOpenTimeout        = 10
+	defaultCheckInterval      = 10 * time.Second
+	defaultInitialBackoff     = time.Second
+	defaultMaxBackoff         = time.Minute
+	maxResumeAttempts         = 5
+	resumeInitialBackoff      = 100 * time.Millisecond
+	resumeMaxBackoff          = 5 * time.Second
+	resumableErrorLabel       = "ResumableChangeStreamError"
+	streamClosedMessage       = "Change stream is closed"
+	fullDocumentPrefix        = "fullDocument."
+	blobBufferSize            = 255 * 1024
+	blobNotFoundMessage       = "Blob not found"
+	blobIDMessage             = "Invalid blob identifier: %s"
+	blobOffsetMessage         = "Invalid offset: %d. It must be higher or equal than 0"
+	pageSizeMessage           = "Invalid page size: %d. It must be higher than 0"
+	invalidTokenMessage       = "Invalid page token"
+	idField                   = "_id"
+	namespaceNotFoundCode     = 26
+	validatorMessage          = "Invalid validator of the collection %s: %s"
+	databaseMissingMessage    = "The name of the DB is not defined in the path of the URI: %s"
+	databaseNameMessage       = "Invalid name of the DB: %q"
+	tenantMissingMessage      = "The tenant is not defined in the context"
+	reservedDatabaseMessage   = "The DB %q is reserved and it can not be used by a tenant"
+	sharedClientMessage       = "The handle shares the client of its Manager"
+	maxDatabaseNameLength     = 63
+	invalidDatabaseCharacters = "/\\. \"$*<>:|?\x00"
+)
+
+// Messages of the validation of the Config
+const (
+	poolSizeMessage           = "Invalid pool size: the minimum %d is higher than the maximum %d"
+	maxConnIdleTimeMessage    = "Invalid max connection idle time: %v. It must be higher or equal than 0"
+	readPreferenceMessage     = "Invalid read preference: %s"
+	readConcernMessage        = "Invalid read concern: %s"
+	writeConcernMessage       = "Invalid write concern: %s. It must be majority or a number higher or equal than 0"
+	compressorMessage         = "Invalid compressor: %s. It must be snappy, zlib or zstd"
+	tlsFileMessage            = "Invalid TLS file: %s"
+	authMechanismMessage      = "Invalid authentication mechanism: %s"
+	serverAPIVersionMessage   = "Invalid server API version: %s"
+	slowQueryThresholdMessage = "Invalid slow query threshold: %v. It must be higher or equal than 0"
+	supervisionMessage        = "Invalid supervision: the durations must be higher or equal than 0"
+	drainTimeoutConfigMessage = "Invalid drain timeout: %v. It must be higher or equal than 0"
+)
+
+var validCompressors = map[string]bool{
+	"snappy": true,
+	"zlib":   true,
+	"zstd":   true,
+}
+
+var validAuthMechanisms = map[string]bool{
+	"SCRAM-SHA-1":   true,
+	"SCRAM-SHA-256": true,
+	"MONGODB-X509":  true,
+	"MONGODB-AWS":   true,
+	"MONGODB-OIDC":  true,
+	"GSSAPI":        true,
+	"PLAIN":         true,
+}
+
+// Messages of the logs
+const (
+	connectedMessage          = "MongoDB connected"
+	connectionFailedMessage   = "MongoDB connection failed"
+	disconnectedMessage       = "MongoDB disconnected"
+	operationMessage          = "MongoDB operation"
+	slowOperationMessage      = "MongoDB slow operation"
+	cursorCloseMessage        = "Error closing cursor"
+	stateChangedMessage       = "MongoDB connection state changed"
+	reconnectedMessage        = "MongoDB reconnected"
+	reconnectionFailedMessage = "MongoDB reconnection failed"
+	drainTimeoutMessage       = "MongoDB operations cancelled by the shutdown"
+	streamResumedMessage      = "MongoDB change stream resumed"
+)
+
+// Names of the operations
 const (
-	clientNotConnected      = "Client is not connected"
-	documentNotFoundMessage = "Document not found"
-	mongoDB                 = "MongoDB"
-	timeoutMessage          = "Invalid timeout: %d. It must be higher than 0"
+	operationInsertOne  = "InsertOne"
+	operationInsertMany = "InsertMany"
+	operationFindOne    = "FindOne"
+	operationFindMany   = "FindMany"
+	operationUpdateOne  = "UpdateOne"
+	operationUpdateMany = "UpdateMany"
+	operationDeleteOne  = "DeleteOne"
+	operationDeleteMany = "DeleteMany"
+	operationPaginate   = "Paginate"
 )
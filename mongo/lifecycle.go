@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// acquire returns the client, the DB of the Manager and the parent context of the operations, and counts an
+// in-flight operation until release is called. It returns a ShutdownError if the Manager is shutting down and a
+// ClientError if it is not connected
+func (manager *Manager) acquire() (*mongo.Client, *mongo.Database, context.Context, error) {
+	root := manager.owner()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	if root.shuttingDown {
+		return nil, nil, nil, &libraryErrors.ShutdownError{Message: shuttingDownMessage}
+	}
+	if root.client == nil {
+		return nil, nil, nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	root.inFlight++
+	if manager.databaseName != "" {
+		return root.client, root.client.Database(manager.databaseName), root.operations, nil
+	}
+	return root.client, root.database, root.operations, nil
+}
+
+// release marks an in-flight operation as finished and wakes up the drains if it was the last one
+func (manager *Manager) release() {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.inFlight--
+	if manager.inFlight == 0 {
+		for _, waiter := range manager.drainWaiters {
+			close(waiter)
+		}
+		manager.drainWaiters = nil
+	}
+}
+
+// connected returns if the Manager has a client or it is shutting down the previous one
+func (manager *Manager) connected() bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.client != nil || manager.shuttingDown
+}
+
+// countInFlight returns the number of operations running
+func (manager *Manager) countInFlight() int {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.inFlight
+}
+
+// drain waits until there are no operations running or the context is done
+func (manager *Manager) drain(ctx context.Context) error {
+	manager.mutex.Lock()
+	if manager.inFlight == 0 {
+		manager.mutex.Unlock()
+		return nil
+	}
+	waiter := make(chan struct{})
+	manager.drainWaiters = append(manager.drainWaiters, waiter)
+	manager.mutex.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
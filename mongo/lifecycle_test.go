@@ -0,0 +1,219 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestLifecycleConcurrentSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	var wait sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			_, err := mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+			if err != nil {
+				// DisconnectDb rejects the operations with a ShutdownError while it drains them
+				var clientError *libraryErrors.ClientError
+				var shutdownError *libraryErrors.ShutdownError
+				assert.True(t, errors.As(err, &clientError) || errors.As(err, &shutdownError), err.Error())
+			}
+		}()
+	}
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+	wait.Wait()
+
+	assert.Nil(t, mongoManager.GetClient())
+	assert.Equal(t, 0, mongoManager.countInFlight())
+	_, err = mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	assert.NoError(t, mongoManager.Close())
+}
+
+func TestLifecycleConcurrentNotConnected(t *testing.T) {
+	mongoManager := new(Manager)
+
+	var wait sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			_, err := mongoManager.FindOne(collectionTest, timeoutTest, map[string]interface{}{})
+			var myErr *libraryErrors.ClientError
+			assert.ErrorAs(t, err, &myErr)
+			assert.Nil(t, mongoManager.GetClient())
+			assert.Equal(t, StateClosed, mongoManager.State())
+			assert.NoError(t, mongoManager.Close())
+		}()
+	}
+	wait.Wait()
+}
+
+func TestCloseIdempotent(t *testing.T) {
+	mongoManager := new(Manager)
+
+	err := mongoManager.DisconnectDb()
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	assert.NoError(t, mongoManager.Close())
+	assert.NoError(t, mongoManager.Close())
+}
+
+func TestConnectDbFailedAlreadyConnected(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:1"))
+	assert.NoError(t, err)
+	mongoManager := &Manager{client: client}
+	mongoManager.operations, mongoManager.cancelOperations = context.WithCancel(context.Background())
+	operations := mongoManager.operations
+
+	err = mongoManager.ConnectDb("mongodb://localhost:1", dbTest, timeoutTest)
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Same(t, client, mongoManager.GetClient())
+	assert.Equal(t, operations, mongoManager.operations)
+	assert.NoError(t, client.Disconnect(context.Background()))
+}
+
+func TestDrainSuccess(t *testing.T) {
+	mongoManager := new(Manager)
+	mongoManager.inFlight = 2
+
+	done := make(chan error)
+	go func() {
+		done <- mongoManager.drain(context.Background())
+	}()
+	mongoManager.release()
+	select {
+	case <-done:
+		t.Fatal("drain returned with operations in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	mongoManager.release()
+	assert.NoError(t, <-done)
+	assert.NoError(t, mongoManager.drain(context.Background()))
+}
+
+func TestDrainFailedTimeout(t *testing.T) {
+	mongoManager := new(Manager)
+	mongoManager.inFlight = 1
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, mongoManager.drain(ctx), context.DeadlineExceeded)
+	assert.Equal(t, 1, mongoManager.countInFlight())
+}
+
+func TestDrainTimeoutFailedInvalidConfig(t *testing.T) {
+	mongoManager := new(Manager)
+
+	err := mongoManager.Configure(WithDrainTimeout(-time.Second))
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestShutdownSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	cancelled, err := mongoManager.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+	assert.Equal(t, StateClosed, mongoManager.State())
+
+	_, err = mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestShutdownCancelInFlight(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	mongoManager.mutex.Lock()
+	mongoManager.inFlight++
+	mongoManager.mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	go func() {
+		<-mongoManager.operations.Done()
+		mongoManager.release()
+	}()
+
+	cancelled, err := mongoManager.Shutdown(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cancelled)
+	assert.Equal(t, 0, mongoManager.countInFlight())
+}
+
+func TestShutdownInsertInFlight(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	const writers = 50
+	var wait sync.WaitGroup
+	var mutex sync.Mutex
+	inserted := 0
+	for i := 0; i < writers; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			result, err := mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"writer": i})
+			if err != nil {
+				// Only the writes rejected before reaching the server can fail
+				var shutdownError *libraryErrors.ShutdownError
+				var clientError *libraryErrors.ClientError
+				assert.True(t, errors.As(err, &shutdownError) || errors.As(err, &clientError), err.Error())
+				return
+			}
+			assert.Equal(t, i, int(result["writer"].(int32)))
+			mutex.Lock()
+			inserted++
+			mutex.Unlock()
+		}(i)
+	}
+	time.Sleep(5 * time.Millisecond)
+	cancelled, err := mongoManager.Shutdown(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+	wait.Wait()
+
+	checkManager, err := CreateManager(os.Getenv("Mongo_URI"), dbTest, timeoutTest)
+	assert.NoError(t, err)
+	documents, err := checkManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, documents, inserted)
+	assert.NoError(t, checkManager.DisconnectDb())
+}
+
+func TestShutdownFailedClientNotCreated(t *testing.T) {
+	mongoManager := new(Manager)
+
+	cancelled, err := mongoManager.Shutdown(context.Background())
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, 0, cancelled)
+}
+
+func TestShutdownRejectOperations(t *testing.T) {
+	mongoManager := new(Manager)
+	mongoManager.shuttingDown = true
+
+	_, err := mongoManager.FindOne(collectionTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.ShutdownError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, "ShutdownError", libraryErrors.Type(err))
+}
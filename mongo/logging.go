@@ -0,0 +1,177 @@
+package mongo
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Redaction defines how the values of the filters are written in the logs of the Manager
+type Redaction int
+
+const (
+	// RedactValues replaces every value of the filters, keeping the keys and operators. It is the default
+	RedactValues Redaction = iota
+	// RedactNone writes the filters as they are
+	RedactNone
+	// RedactKeys replaces only the values of the keys defined in SetRedaction
+	RedactKeys
+)
+
+// SetLogger is the function inside the Manager to define the logger for the structured records.
+// If it is not defined, slog.Default() is used. The operations are logged with the Debug level, the slow
+// operations with the Warn level and the internal errors with the Error level
+// logger: It is the logger to use
+func (manager *Manager) SetLogger(logger *slog.Logger) {
+	manager.logger = logger
+}
+
+// SetSlowQueryThreshold is the function inside the Manager to log with the Warn level the operations that
+// take longer than the threshold
+// threshold: It is the minimum duration of a slow operation. 0 disables it
+func (manager *Manager) SetSlowQueryThreshold(threshold time.Duration) {
+	manager.slowQueryThreshold = threshold
+}
+
+// SetRedaction is the function inside the Manager to define how the filters are written in the logs
+// redaction: It is the mode of the redaction
+// keys: They are the keys whose values are replaced when the mode is RedactKeys
+func (manager *Manager) SetRedaction(redaction Redaction, keys ...string) {
+	manager.redaction = redaction
+	manager.redactedKeys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		manager.redactedKeys[key] = true
+	}
+}
+
+// getLogger returns the logger of the Manager or the default one
+func (manager *Manager) getLogger() *slog.Logger {
+	if manager.logger == nil {
+		return slog.Default()
+	}
+	return manager.logger
+}
+
+// logOperation writes the record of an operation and, if it is slow, the record of the slow operation
+func (manager *Manager) logOperation(operation, collection string, filter map[string]interface{}, start time.Time, count int, err error) {
+	duration := time.Since(start)
+	logger := manager.getLogger()
+	slow := manager.slowQueryThreshold > 0 && duration >= manager.slowQueryThreshold
+	if !slow && !logger.Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+
+	countKey := "returned"
+	if operation == operationUpdateOne || operation == operationUpdateMany ||
+		operation == operationDeleteOne || operation == operationDeleteMany {
+		countKey = "matched"
+	}
+	attributes := []slog.Attr{
+		slog.String("db", mongoDB),
+		slog.String("operation", operation),
+		slog.String("collection", collection),
+		slog.Duration("duration", duration),
+		slog.Int(countKey, count),
+	}
+	if filter != nil {
+		attributes = append(attributes, slog.Any("filter", manager.redact(filter)))
+	}
+	if err != nil {
+		attributes = append(attributes, slog.String("error_class", libraryErrors.Type(err)), slog.String("error", err.Error()))
+	}
+
+	logger.LogAttrs(context.Background(), slog.LevelDebug, operationMessage, attributes...)
+	if slow {
+		attributes = append(attributes, slog.Duration("threshold", manager.slowQueryThreshold))
+		logger.LogAttrs(context.Background(), slog.LevelWarn, slowOperationMessage, attributes...)
+	}
+}
+
+// logConnection writes the record of a connection or a disconnection
+func (manager *Manager) logConnection(message, dbName string, start time.Time, err error) {
+	attributes := []slog.Attr{
+		slog.String("db", mongoDB),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if dbName != "" {
+		attributes = append(attributes, slog.String("database", dbName))
+	}
+	if err != nil {
+		attributes = append(attributes, slog.String("error_class", libraryErrors.Type(err)), slog.String("error", err.Error()))
+	}
+	manager.getLogger().LogAttrs(context.Background(), slog.LevelDebug, message, attributes...)
+}
+
+// redact returns a copy of the filter with the values replaced according to the redaction of the Manager
+func (manager *Manager) redact(filter map[string]interface{}) map[string]interface{} {
+	if manager.redaction == RedactNone {
+		return filter
+	}
+	redacted := make(map[string]interface{}, len(filter))
+	for key, value := range filter {
+		redacted[key] = manager.redactValue(key, value)
+	}
+	return redacted
+}
+
+// redactValue returns the value of the key redacted according to the redaction of the Manager. The documents and
+// the arrays, also of the types of the driver (bson.M, bson.D and bson.A), are redacted field by field
+func (manager *Manager) redactValue(key string, value interface{}) interface{} {
+	if manager.redaction == RedactKeys && manager.redactedKeys[key] {
+		return redactedValue
+	}
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return manager.redact(typedValue)
+	case bson.M:
+		return bson.M(manager.redact(typedValue))
+	case bson.D:
+		redacted := make(bson.D, len(typedValue))
+		for index, element := range typedValue {
+			redacted[index] = bson.E{Key: element.Key, Value: manager.redactValue(element.Key, element.Value)}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(typedValue))
+		for index, item := range typedValue {
+			redacted[index] = manager.redactValue(key, item)
+		}
+		return redacted
+	case bson.A:
+		redacted := make(bson.A, len(typedValue))
+		for index, item := range typedValue {
+			redacted[index] = manager.redactValue(key, item)
+		}
+		return redacted
+	case []map[string]interface{}:
+		redacted := make([]interface{}, len(typedValue))
+		for index, item := range typedValue {
+			redacted[index] = manager.redact(item)
+		}
+		return redacted
+	default:
+		if manager.redaction == RedactValues {
+			return redactedValue
+		}
+		return value
+	}
+}
+
+// countDocument returns the number of documents of a single document result
+func countDocument(document map[string]interface{}) int {
+	if document == nil {
+		return 0
+	}
+	return 1
+}
+
+// countDeleted returns the number of documents deleted by DeleteOne
+func countDeleted(err error) int {
+	if err != nil {
+		return 0
+	}
+	return 1
+}
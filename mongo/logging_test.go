@@ -0,0 +1,123 @@
+package mongo
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func initializeLogger(level slog.Level) (*bytes.Buffer, *slog.Logger) {
+	buffer := new(bytes.Buffer)
+	return buffer, slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: level}))
+}
+
+func readRecords(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogOperationFailedClientNotCreated(t *testing.T) {
+	buffer, logger := initializeLogger(slog.LevelDebug)
+	mongoManager := new(Manager)
+	mongoManager.SetLogger(logger)
+
+	_, err := mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{"test": "secret"})
+	assert.Error(t, err)
+
+	records := readRecords(t, buffer)
+	assert.Len(t, records, 1)
+	assert.Equal(t, operationMessage, records[0]["msg"])
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, operationFindMany, records[0]["operation"])
+	assert.Equal(t, collectionTest, records[0]["collection"])
+	assert.Equal(t, "ClientError", records[0]["error_class"])
+	assert.Equal(t, float64(0), records[0]["returned"])
+	assert.Equal(t, map[string]interface{}{"test": redactedValue}, records[0]["filter"])
+}
+
+func TestLogOperationSlowQuery(t *testing.T) {
+	buffer, logger := initializeLogger(slog.LevelWarn)
+	mongoManager := new(Manager)
+	mongoManager.SetLogger(logger)
+	mongoManager.SetSlowQueryThreshold(time.Nanosecond)
+
+	err := mongoManager.DeleteOne(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.Error(t, err)
+
+	records := readRecords(t, buffer)
+	assert.Len(t, records, 1)
+	assert.Equal(t, slowOperationMessage, records[0]["msg"])
+	assert.Equal(t, operationDeleteOne, records[0]["operation"])
+	assert.Equal(t, float64(0), records[0]["matched"])
+}
+
+func TestLogOperationDisabled(t *testing.T) {
+	buffer, logger := initializeLogger(slog.LevelInfo)
+	mongoManager := new(Manager)
+	mongoManager.SetLogger(logger)
+
+	_, err := mongoManager.FindOne(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.Error(t, err)
+	assert.Equal(t, "", buffer.String())
+}
+
+func TestRedaction(t *testing.T) {
+	filter := map[string]interface{}{
+		"name":     "test",
+		"password": "secret",
+		"age":      map[string]interface{}{"$gt": 18},
+		"$or":      []interface{}{map[string]interface{}{"password": "other"}, map[string]interface{}{"name": "other"}},
+	}
+	mongoManager := new(Manager)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":     redactedValue,
+		"password": redactedValue,
+		"age":      map[string]interface{}{"$gt": redactedValue},
+		"$or":      []interface{}{map[string]interface{}{"password": redactedValue}, map[string]interface{}{"name": redactedValue}},
+	}, mongoManager.redact(filter))
+
+	mongoManager.SetRedaction(RedactKeys, "password")
+	assert.Equal(t, map[string]interface{}{
+		"name":     "test",
+		"password": redactedValue,
+		"age":      map[string]interface{}{"$gt": 18},
+		"$or":      []interface{}{map[string]interface{}{"password": redactedValue}, map[string]interface{}{"name": "other"}},
+	}, mongoManager.redact(filter))
+
+	mongoManager.SetRedaction(RedactNone)
+	assert.Equal(t, filter, mongoManager.redact(filter))
+
+	filter = map[string]interface{}{
+		"$or":     []interface{}{bson.M{"password": "x"}, bson.M{"name": "y"}},
+		"$and":    bson.A{bson.D{{Key: "password", Value: "z"}}},
+		"profile": bson.M{"password": "w", "age": bson.M{"$gt": 18}},
+	}
+	mongoManager.SetRedaction(RedactKeys, "password")
+	assert.Equal(t, map[string]interface{}{
+		"$or":     []interface{}{bson.M{"password": redactedValue}, bson.M{"name": "y"}},
+		"$and":    bson.A{bson.D{{Key: "password", Value: redactedValue}}},
+		"profile": bson.M{"password": redactedValue, "age": bson.M{"$gt": 18}},
+	}, mongoManager.redact(filter))
+
+	mongoManager.SetRedaction(RedactValues)
+	assert.Equal(t, map[string]interface{}{
+		"$or":     []interface{}{bson.M{"password": redactedValue}, bson.M{"name": redactedValue}},
+		"$and":    bson.A{bson.D{{Key: "password", Value: redactedValue}}},
+		"profile": bson.M{"password": redactedValue, "age": bson.M{"$gt": redactedValue}},
+	}, mongoManager.redact(filter))
+}
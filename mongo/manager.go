@@ -4,72 +4,245 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"reflect"
+	"sync"
 	"time"
 
 	libraryErrors "github.com/cristianat98/dbclientgo/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // Manager is the structure to manage the connections and operations to the MongoDB
+// mutex: It protects the connection (client, database, dbURI and timeout), the supervision and the in-flight operations
 // client: It is directly the client to the MongoDB
 // database: It is the database to connect in MongoDB
+// dbURI: It is the URI of the last connection, used to reconnect
+// timeout: It is the timeout of the last connection, used to reconnect
+// root: It is the Manager that owns the connection when the Manager is a copy created by WithConsistency or ForDatabase
+// databaseName: It is the name of the DB of the handles created by ForDatabase. It is empty in the rest of Managers
+// databases: They are the handles created by ForDatabase
+// state: It is the state of the connection
+// stateCallbacks: They are the functions called when the state changes
+// supervisorStop: It is closed to stop the supervision of the connection
+// supervisorDone: It is closed when the supervision has stopped
+// operations: It is the parent context of the operations. It is cancelled by Shutdown
+// cancelOperations: It cancels the operations context
+// shuttingDown: It is true while Shutdown waits for the in-flight operations
+// inFlight: It is the number of operations running
+// drainWaiters: They are closed when there are no operations running
+// logger: It is the logger for the structured records. If it is nil, slog.Default() is used
+// slowQueryThreshold: It is the minimum duration of the operations logged as slow. 0 disables it
+// redaction: It defines how the filters are written in the logs
+// redactedKeys: They are the keys redacted when redaction is RedactKeys
+// config: It contains the settings of the client created in ConnectDb
+// collectionOptions: They are the options of the collections (consistency), defined with WithConsistency
 type Manager struct {
-	client   *mongo.Client
-	database *mongo.Database
+	mutex              sync.RWMutex
+	client             *mongo.Client
+	database           *mongo.Database
+	dbURI              string
+	timeout            int64
+	root               *Manager
+	databaseName       string
+	databases          map[string]*Manager
+	state              ConnectionState
+	stateCallbacks     []func(from, to ConnectionState)
+	supervisorStop     chan struct{}
+	supervisorDone     chan struct{}
+	operations         context.Context
+	cancelOperations   context.CancelFunc
+	shuttingDown       bool
+	inFlight           int
+	drainWaiters       []chan struct{}
+	logger             *slog.Logger
+	slowQueryThreshold time.Duration
+	redaction          Redaction
+	redactedKeys       map[string]bool
+	config             Config
+	collectionOptions  *options.CollectionOptions
 }
 
 // CreateManager is the constructor for the Manager. If it can not connect to the MongoDB, it will fail
 // dbURI: It is the URI to connect to the MongoDB
 // dbName: It is the name of the DB inside the MongoDB
 // timeout: It is the time to define the timeout inside the Manager
+// opts: They are the options to configure the client (pool, TLS, authentication...). They are validated before connecting
 // It returns the Manager instance and an error
-func CreateManager(dbURI, dbName string, timeout int64) (*Manager, error) {
+func CreateManager(dbURI, dbName string, timeout int64, opts ...Option) (*Manager, error) {
 	mongoManager := new(Manager)
+	if err := mongoManager.Configure(opts...); err != nil {
+		return nil, err
+	}
 	if err := mongoManager.ConnectDb(dbURI, dbName, timeout); err != nil {
 		return nil, err
 	}
 	return mongoManager, nil
 }
 
-// ConnectDb is the function inside the Manager to connect to the MongoDB
+// ConnectDb is the function inside the Manager to connect to the MongoDB. It returns a ClientError if the
+// Manager is already connected, so the previous client must be closed with DisconnectDb first, and in the copies
+// created by ForDatabase and WithConsistency, because they share the client of their Manager
 // dbURI: It is the URI to connect to the MongoDB
 // dbName: It is the name of the DB inside the MongoDB
 // timeout: It is the time to define the timeout inside the Manager
 // It returns an error in case there was some error
 func (manager *Manager) ConnectDb(dbURI, dbName string, timeout int64) error {
+	start := time.Now()
+	err := manager.connectDb(dbURI, dbName, timeout)
+	if err != nil {
+		manager.logConnection(connectionFailedMessage, dbName, start, err)
+	} else {
+		manager.logConnection(connectedMessage, dbName, start, nil)
+	}
+	return err
+}
+
+// connectDb contains the logic of ConnectDb, without logging
+func (manager *Manager) connectDb(dbURI, dbName string, timeout int64) error {
 	if timeout < 1 {
 		return &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
-	opts := options.Client().ApplyURI(dbURI).SetServerAPIOptions(serverAPI)
+	if manager.root != nil {
+		return &libraryErrors.ClientError{Message: sharedClientMessage}
+	}
+	root := manager.owner()
+	if root.connected() {
+		return &libraryErrors.ClientError{Message: alreadyConnectedMessage}
+	}
+	root.setState(StateConnecting)
+
+	client, err := root.newClient(dbURI, dbName, timeout)
+	if err != nil {
+		root.setState(StateClosed)
+		return err
+	}
+
+	root.mutex.Lock()
+	if root.client != nil || root.shuttingDown {
+		// Another ConnectDb won the race: the new client is closed so it does not leak
+		root.mutex.Unlock()
+		root.setState(StateConnected)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		defer cancel()
+		_ = client.Disconnect(ctx)
+		return &libraryErrors.ClientError{Message: alreadyConnectedMessage}
+	}
+	root.client = client
+	root.database = client.Database(dbName)
+	root.dbURI = dbURI
+	root.timeout = timeout
+	root.operations, root.cancelOperations = context.WithCancel(context.Background())
+	// The supervisor starts with the client, so a Shutdown always finds and stops it
+	if root.config.Supervision != nil {
+		root.startSupervisor(*root.config.Supervision)
+	}
+	root.mutex.Unlock()
+	root.setState(StateConnected)
+	return nil
+}
+
+// newClient creates a client connected to the MongoDB and checks the connection with a ping
+func (manager *Manager) newClient(dbURI, dbName string, timeout int64) (*mongo.Client, error) {
+	opts, err := manager.config.clientOptions(dbURI)
+	if err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
 	client, err := mongo.Connect(ctx, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var result bson.M
 	if err := client.Database(dbName).RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Decode(&result); err != nil {
-		return &libraryErrors.ConnectionError{Db: mongoDB}
+		_ = client.Disconnect(context.Background())
+		return nil, &libraryErrors.ConnectionError{Db: mongoDB}
 	}
-	manager.client = client
-	manager.database = client.Database(dbName)
-	return nil
+	return client, nil
 }
 
-// DisconnectDb is the function inside the Manager to disconnect from the MongoDB
+// DisconnectDb is the function inside the Manager to disconnect from the MongoDB. It stops the supervision
+// of the connection, so the Manager does not reconnect until ConnectDb is called again. The new operations are
+// rejected and the in-flight ones have until the drain timeout to finish before they are cancelled (see Shutdown)
 func (manager *Manager) DisconnectDb() error {
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return &libraryErrors.ClientError{Message: clientNotConnected}
+	root := manager.owner()
+	root.mutex.RLock()
+	drainTimeout := root.config.DrainTimeout
+	if drainTimeout == 0 {
+		drainTimeout = time.Duration(root.timeout) * time.Second
 	}
-	return manager.client.Disconnect(context.TODO())
+	root.mutex.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	_, err := manager.Shutdown(ctx)
+	return err
+}
+
+// Shutdown is the function inside the Manager to disconnect from the MongoDB gracefully. It does nothing in the
+// copies created by ForDatabase and WithConsistency, because they share the client of their Manager. The new operations are
+// rejected with a ShutdownError while it runs and with a ClientError after it. The in-flight operations can finish
+// until ctx is done. Then, they are cancelled and the connection pool is closed
+// ctx: It is the context that limits the time to wait for the in-flight operations
+// It returns the number of operations cancelled and an error
+func (manager *Manager) Shutdown(ctx context.Context) (int, error) {
+	if manager.root != nil {
+		return 0, nil
+	}
+	root := manager.owner()
+	root.mutex.Lock()
+	client, database, timeout, cancelOperations := root.client, root.database, root.timeout, root.cancelOperations
+	if client == nil || root.shuttingDown {
+		root.mutex.Unlock()
+		return 0, &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	root.client, root.database = nil, nil
+	root.shuttingDown = true
+	root.mutex.Unlock()
+	root.stopSupervisor()
+	defer func() {
+		root.mutex.Lock()
+		root.shuttingDown = false
+		root.mutex.Unlock()
+		root.setState(StateClosed)
+	}()
+
+	start := time.Now()
+	cancelled := 0
+	if root.drain(ctx) != nil {
+		cancelled = root.countInFlight()
+		manager.getLogger().Warn(drainTimeoutMessage, slog.String("db", mongoDB), slog.Int("cancelled", cancelled))
+	}
+	cancelOperations()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	_ = root.drain(closeCtx)
+	err := client.Disconnect(closeCtx)
+	if errors.Is(err, mongo.ErrClientDisconnected) {
+		err = &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	manager.logConnection(disconnectedMessage, database.Name(), start, err)
+	return cancelled, err
+}
+
+// Close is the function inside the Manager to disconnect from the MongoDB like DisconnectDb, but it can be
+// called many times: it returns nil if the Manager is already disconnected
+func (manager *Manager) Close() error {
+	err := manager.DisconnectDb()
+	var clientError *libraryErrors.ClientError
+	if errors.As(err, &clientError) {
+		return nil
+	}
+	return err
 }
 
 // InsertOne is the function inside the Manager to insert a document in the collection
@@ -78,16 +251,27 @@ func (manager *Manager) DisconnectDb() error {
 // document: It is the document to add in the collection
 // It returns the new document inserted in the collection and an error
 func (manager *Manager) InsertOne(collection string, timeout int64, document map[string]interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.insertOne(collection, timeout, document)
+	manager.logOperation(operationInsertOne, collection, nil, start, countDocument(result), err)
+	return result, err
+}
+
+// insertOne contains the logic of InsertOne, without logging
+func (manager *Manager) insertOne(collection string, timeout int64, document map[string]interface{}) (map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
 
-	resultInsert, err := manager.database.Collection(collection).InsertOne(ctx, document)
+	resultInsert, err := mongoCollection.InsertOne(ctx, document)
+	if errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+		return unacknowledgedDocuments([]map[string]interface{}{document}, []interface{}{resultInsert.InsertedID})[0], nil
+	}
 	if err != nil {
 		if _, ok := err.(mongo.CommandError); ok {
 			return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -98,8 +282,7 @@ func (manager *Manager) InsertOne(collection string, timeout int64, document map
 		}
 	}
 
-	documentReturned, err := manager.FindOne(collection, timeout, map[string]interface{}{"_id": resultInsert.InsertedID})
-	return documentReturned, err
+	return findDocument(ctx, primaryCollection(mongoCollection), map[string]interface{}{"_id": resultInsert.InsertedID})
 }
 
 // InsertMany is the function inside the Manager to insert many documents in the collection
@@ -108,25 +291,41 @@ func (manager *Manager) InsertOne(collection string, timeout int64, document map
 // documents: It is the list of documents to insert in the collection
 // It returns the new documents inserted in the collection and an error
 func (manager *Manager) InsertMany(collection string, timeout int64, documents []map[string]interface{}) ([]map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.insertMany(collection, timeout, documents)
+	manager.logOperation(operationInsertMany, collection, nil, start, len(result), err)
+	return result, err
+}
+
+// insertMany contains the logic of InsertMany, without logging
+func (manager *Manager) insertMany(collection string, timeout int64, documents []map[string]interface{}) ([]map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
 
 	var documentsParsed []interface{}
 	for _, item := range documents {
 		documentsParsed = append(documentsParsed, item)
 	}
 
-	insertResult, errInsert := manager.database.Collection(collection).InsertMany(ctx, documentsParsed)
+	insertResult, errInsert := mongoCollection.InsertMany(ctx, documentsParsed)
+	if errors.Is(errInsert, mongo.ErrUnacknowledgedWrite) {
+		return unacknowledgedDocuments(documents, insertResult.InsertedIDs), nil
+	}
 	var documentsInserted []map[string]interface{}
+	var insertedIDs []interface{}
+	if insertResult != nil {
+		// The result is nil when the driver fails before writing, for example with the operations cancelled by Shutdown
+		insertedIDs = insertResult.InsertedIDs
+	}
 
-	for _, id := range insertResult.InsertedIDs {
-		documentReturned, err := manager.FindOne(collection, timeout, map[string]interface{}{"_id": id})
+	for _, id := range insertedIDs {
+		documentReturned, err := findDocument(ctx, primaryCollection(mongoCollection), map[string]interface{}{"_id": id})
 		if err != nil {
 			if _, ok := err.(mongo.CommandError); ok {
 				return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -155,16 +354,40 @@ func (manager *Manager) InsertMany(collection string, timeout int64, documents [
 // filter: It is the filter to find the document inside the MongoDB
 // It returns the first document matching with the filter and an error
 func (manager *Manager) FindOne(collection string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.findOne(collection, timeout, filter)
+	manager.logOperation(operationFindOne, collection, filter, start, countDocument(result), err)
+	return result, err
+}
+
+// findOne contains the logic of FindOne, without logging
+func (manager *Manager) findOne(collection string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
+	return findDocument(ctx, mongoCollection, filter)
+}
 
-	resultFind := manager.database.Collection(collection).FindOne(ctx, filter)
+// primaryCollection returns the collection with the primary read preference. The writes read back their documents
+// with it, because a secondary of the read preference of WithConsistency may not have them yet
+func primaryCollection(mongoCollection *mongo.Collection) *mongo.Collection {
+	primary, err := mongoCollection.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+	if err != nil {
+		return mongoCollection
+	}
+	return primary
+}
+
+// findDocument returns the first document that matches the filter in a collection already acquired with
+// getCollection. The writes use it to read back their documents without acquiring the client again, so the
+// in-flight writes can finish while Shutdown drains them
+func findDocument(ctx context.Context, mongoCollection *mongo.Collection, filter interface{}) (map[string]interface{}, error) {
+	resultFind := mongoCollection.FindOne(ctx, filter)
 	if err := resultFind.Err(); err != nil {
 		if _, ok := err.(mongo.CommandError); ok {
 			return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -185,17 +408,30 @@ func (manager *Manager) FindOne(collection string, timeout int64, filter map[str
 // filter: It is the filter to find documents inside the MongoDB
 // It returns a list of documents (it may be empty) and an error
 func (manager *Manager) FindMany(collection string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.findMany(collection, timeout, filter)
+	manager.logOperation(operationFindMany, collection, filter, start, len(result), err)
+	return result, err
+}
+
+// findMany contains the logic of FindMany, without logging
+func (manager *Manager) findMany(collection string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
+	return manager.findDocuments(ctx, mongoCollection, filter)
+}
 
+// findDocuments returns the documents that match the filter in a collection already acquired with getCollection,
+// like findDocument
+func (manager *Manager) findDocuments(ctx context.Context, mongoCollection *mongo.Collection, filter interface{}) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
-	cursor, err := manager.database.Collection(collection).Find(ctx, filter)
+	cursor, err := mongoCollection.Find(ctx, filter)
 	if err != nil {
 		if _, ok := err.(mongo.CommandError); ok {
 			return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -205,7 +441,7 @@ func (manager *Manager) FindMany(collection string, timeout int64, filter map[st
 
 	defer func() {
 		if err := cursor.Close(ctx); err != nil {
-			log.Printf("Error closing cursor: %v", err)
+			manager.getLogger().Error(cursorCloseMessage, slog.String("db", mongoDB), slog.String("collection", mongoCollection.Name()), slog.String("error", err.Error()))
 		}
 	}()
 
@@ -226,14 +462,22 @@ func (manager *Manager) FindMany(collection string, timeout int64, filter map[st
 // update: It contains the new changes to apply in the document
 // It returns the document updated and an error
 func (manager *Manager) UpdateOne(collection string, timeout int64, filter map[string]interface{}, update interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.updateOne(collection, timeout, filter, update)
+	manager.logOperation(operationUpdateOne, collection, filter, start, countDocument(result), err)
+	return result, err
+}
+
+// updateOne contains the logic of UpdateOne, without logging
+func (manager *Manager) updateOne(collection string, timeout int64, filter map[string]interface{}, update interface{}) (map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
 
 	m, ok := update.(map[string]interface{})
 	if !ok {
@@ -241,7 +485,10 @@ func (manager *Manager) UpdateOne(collection string, timeout int64, filter map[s
 	}
 
 	var documentReturned bson.M
-	err := manager.database.Collection(collection).FindOneAndUpdate(ctx, filter, bson.M{"$set": m}).Decode(&documentReturned)
+	err = mongoCollection.FindOneAndUpdate(ctx, filter, bson.M{"$set": m}).Decode(&documentReturned)
+	if errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+		return nil, nil
+	}
 	if err != nil {
 		if _, ok := err.(mongo.CommandError); ok {
 			return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -251,7 +498,7 @@ func (manager *Manager) UpdateOne(collection string, timeout int64, filter map[s
 		return nil, err
 	}
 
-	return manager.FindOne(collection, timeout, map[string]interface{}{"_id": documentReturned["_id"]})
+	return findDocument(ctx, primaryCollection(mongoCollection), map[string]interface{}{"_id": documentReturned["_id"]})
 }
 
 // UpdateMany is the function for updating multiple documents that match the filter
@@ -261,21 +508,29 @@ func (manager *Manager) UpdateOne(collection string, timeout int64, filter map[s
 // update: The new content for the documents found
 // It returns the documents updated and an error
 func (manager *Manager) UpdateMany(collection string, timeout int64, filter map[string]interface{}, update interface{}) ([]map[string]interface{}, error) {
+	start := time.Now()
+	result, err := manager.updateMany(collection, timeout, filter, update)
+	manager.logOperation(operationUpdateMany, collection, filter, start, len(result), err)
+	return result, err
+}
+
+// updateMany contains the logic of UpdateMany, without logging
+func (manager *Manager) updateMany(collection string, timeout int64, filter map[string]interface{}, update interface{}) ([]map[string]interface{}, error) {
 	if timeout < 1 {
 		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer release()
 	opts := options.Update().SetUpsert(false)
-	defer cancel()
 
 	m, ok := update.(map[string]interface{})
 	if !ok {
 		return nil, errors.New("i is not a map[string]interface{}")
 	}
-	documentsFilter, err := manager.FindMany(collection, timeout, filter)
+	documentsFilter, err := manager.findDocuments(ctx, primaryCollection(mongoCollection), filter)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +538,10 @@ func (manager *Manager) UpdateMany(collection string, timeout int64, filter map[
 		return nil, &libraryErrors.NotExistError{Message: documentNotFoundMessage}
 	}
 
-	resultUpdate, err := manager.database.Collection(collection).UpdateMany(ctx, filter, bson.M{"$set": m}, opts)
+	resultUpdate, err := mongoCollection.UpdateMany(ctx, filter, bson.M{"$set": m}, opts)
+	if errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +551,7 @@ func (manager *Manager) UpdateMany(collection string, timeout int64, filter map[
 
 	var documentsModified []map[string]interface{}
 	for _, document := range documentsFilter {
-		documentReturned, err := manager.FindOne(collection, timeout, map[string]interface{}{"_id": document["_id"]})
+		documentReturned, err := findDocument(ctx, primaryCollection(mongoCollection), map[string]interface{}{"_id": document["_id"]})
 		if err != nil {
 			if _, ok := err.(mongo.CommandError); ok {
 				return nil, &libraryErrors.ConnectionError{Db: mongoDB}
@@ -312,20 +570,34 @@ func (manager *Manager) UpdateMany(collection string, timeout int64, filter map[
 // filter: It is the filter to find the document to delete
 // It returns an error in case a document was not deleted
 func (manager *Manager) DeleteOne(collection string, timeout int64, filter map[string]interface{}) error {
+	start := time.Now()
+	err := manager.deleteOne(collection, timeout, filter)
+	manager.logOperation(operationDeleteOne, collection, filter, start, countDeleted(err), err)
+	return err
+}
+
+// deleteOne contains the logic of DeleteOne, without logging
+func (manager *Manager) deleteOne(collection string, timeout int64, filter map[string]interface{}) error {
 	if timeout < 1 {
 		return &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
 
-	result, err := manager.database.Collection(collection).DeleteOne(ctx, filter)
+	result, err := mongoCollection.DeleteOne(ctx, filter)
+	if errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
 	if result.DeletedCount == 0 {
 		return &libraryErrors.NotExistError{Message: documentNotFoundMessage}
 	}
-	return err
+	return nil
 }
 
 // DeleteMany is the function inside the Manager to delete all the documents that match with the filter
@@ -334,21 +606,95 @@ func (manager *Manager) DeleteOne(collection string, timeout int64, filter map[s
 // filter: It is the filter to find the documents to delete
 // It returns the number of documents deleted and an error
 func (manager *Manager) DeleteMany(collection string, timeout int64, filter map[string]interface{}) (int, error) {
+	start := time.Now()
+	result, err := manager.deleteMany(collection, timeout, filter)
+	manager.logOperation(operationDeleteMany, collection, filter, start, result, err)
+	return result, err
+}
+
+// deleteMany contains the logic of DeleteMany, without logging
+func (manager *Manager) deleteMany(collection string, timeout int64, filter map[string]interface{}) (int, error) {
 	if timeout < 1 {
 		return 0, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
 	}
-	if manager.client == nil || manager.client.Ping(context.TODO(), nil) != nil {
-		return 0, &libraryErrors.ClientError{Message: clientNotConnected}
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return 0, err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
-	defer cancel()
+	defer release()
+
+	result, err := mongoCollection.DeleteMany(ctx, filter)
+	if errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int(result.DeletedCount), nil
+}
+
+// unacknowledgedDocuments returns copies of the documents sent with their _id. The writes with the
+// WriteUnacknowledged concern can not be read back, because the server does not confirm them
+func unacknowledgedDocuments(documents []map[string]interface{}, ids []interface{}) []map[string]interface{} {
+	results := make([]map[string]interface{}, len(documents))
+	for i, document := range documents {
+		results[i] = make(map[string]interface{}, len(document)+1)
+		for key, value := range document {
+			results[i][key] = value
+		}
+		if i < len(ids) {
+			results[i]["_id"] = ids[i]
+		}
+	}
+	return results
+}
+
+// owner returns the Manager that owns the connection. The copies created by WithConsistency share the
+// connection of the Manager that created them
+func (manager *Manager) owner() *Manager {
+	if manager.root != nil {
+		return manager.root
+	}
+	return manager
+}
+
+// getCollection returns the collection with the options of the Manager and the context of the operation, or a
+// ClientError if the Manager is not connected and a ShutdownError if it is shutting down. The operation is counted as
+// in-flight until release is called, so DisconnectDb and Shutdown wait for it
+func (manager *Manager) getCollection(name string, timeout int64) (*mongo.Collection, context.Context, func(), error) {
+	client, database, operations, err := manager.acquire()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	root := manager.owner()
+	ctx, cancel := context.WithTimeout(operations, time.Duration(timeout)*time.Second)
+	release := func() {
+		cancel()
+		root.release()
+	}
+	if client.Ping(ctx, nil) != nil {
+		release()
+		return nil, nil, nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	if manager.collectionOptions == nil {
+		return database.Collection(name), ctx, release, nil
+	}
+	return database.Collection(name, manager.collectionOptions), ctx, release, nil
+}
 
-	result, err := manager.database.Collection(collection).DeleteMany(ctx, filter)
-	return int(result.DeletedCount), err
+// SetPoolMonitor is the function inside the Manager to define the monitor of the connection pool.
+// It must be called before ConnectDb
+// poolMonitor: It is the monitor to add to the client
+func (manager *Manager) SetPoolMonitor(poolMonitor *event.PoolMonitor) {
+	manager.config.PoolMonitor = poolMonitor
 }
 
 // GetClient is the function inside the Manager that allows to get the mongoClient to use some native functions
 // It returns the mongoClient
 func (manager *Manager) GetClient() *mongo.Client {
-	return manager.client
+	root := manager.owner()
+	root.mutex.RLock()
+	defer root.mutex.RUnlock()
+	return root.client
 }
@@ -5,6 +5,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/cristianat98/dbclientgo/database"
+	"github.com/cristianat98/dbclientgo/databasetest"
 	libraryErrors "github.com/cristianat98/dbclientgo/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -41,6 +43,17 @@ func initializeDb() (*Manager, error) {
 	return mongoManager, nil
 }
 
+func TestManagerConformance(t *testing.T) {
+	databasetest.RunSuite(t, func() database.DatabaseInterface {
+		mongoManager, err := initializeDb()
+		if err != nil {
+			t.Log(err)
+			return nil
+		}
+		return mongoManager
+	})
+}
+
 func TestConnectDbSuccess(t *testing.T) {
 	mongoURI := os.Getenv("Mongo_URI")
 	assert.NotEqual(t, "", mongoURI)
@@ -59,14 +72,6 @@ func TestConnectDbFailedConnectionError(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestDisconnectDbSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestDisconnectDbFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -75,60 +80,6 @@ func TestDisconnectDbFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestInsertOneSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-
-	result, err := mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	expected := insertDocument
-	expected["_id"] = result["_id"]
-	assert.NoError(t, err)
-	assert.Equal(t, expected, result)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestInsertOneFailedIdAlreadyExists(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	result, err := mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-	insertDocument["_id"] = result["_id"]
-
-	result, err = mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.AlreadyExistError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestInsertOneFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	result, err := mongoManager.InsertOne(collectionTest, 0, insertDocument)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestInsertOneFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -141,82 +92,6 @@ func TestInsertOneFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestInsertManySuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument1 := map[string]interface{}{
-		"document1": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument1)
-	insertDocument2 := map[string]interface{}{
-		"document2": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument2)
-	result, err := mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	assert.NoError(t, err)
-	for index, item := range result {
-		expected := insertDocuments[index]
-		expected["_id"] = item["_id"]
-		assert.Equal(t, expected, item)
-	}
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestInsertManyFailedIdAlreadyExists(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument1 := map[string]interface{}{
-		"document1": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument1)
-	insertDocument2 := map[string]interface{}{
-		"document2": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument2)
-	result, err := mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	assert.NoError(t, err)
-
-	insertDocument2["_id"] = result[1]["_id"]
-	result, err = mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	var expected []map[string]interface{}
-	expected = append(expected, insertDocument1)
-	expected[0]["_id"] = result[0]["_id"]
-	assert.Equal(t, expected, result)
-	var myErr *libraryErrors.AlreadyExistError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestInsertManyFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument1 := map[string]interface{}{
-		"document1": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument1)
-	insertDocument2 := map[string]interface{}{
-		"document2": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument2)
-	result, err := mongoManager.InsertMany(collectionTest, 0, insertDocuments)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestInsertManyFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -235,57 +110,6 @@ func TestInsertManyFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestFindOneSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	resultInsert, err := mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-
-	resultFind, err := mongoManager.FindOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-	assert.Equal(t, resultInsert, resultFind)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestFindOneFailedNoExist(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	document := map[string]interface{}{
-		"test": "test",
-	}
-
-	result, err := mongoManager.FindOne(collectionTest, timeoutTest, document)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.NotExistError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestFindOneFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	document := map[string]interface{}{
-		"test": "test",
-	}
-	result, err := mongoManager.FindOne(collectionTest, 0, document)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestFindOneFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -298,43 +122,6 @@ func TestFindOneFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestFindManySuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument := map[string]interface{}{
-		"document": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument)
-	insertDocuments = append(insertDocuments, insertDocument)
-	resultInsert, err := mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	assert.NoError(t, err)
-
-	resultFind, err := mongoManager.FindMany(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-	assert.Equal(t, resultInsert, resultFind)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestFindManyFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	document := map[string]interface{}{
-		"test": "test",
-	}
-	result, err := mongoManager.FindMany(collectionTest, 0, document)
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestFindManyFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -347,78 +134,6 @@ func TestFindManyFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestUpdateOneSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	resultInsert, err := mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-
-	updateDocument := map[string]interface{}{
-		"test": "test2",
-	}
-	resultUpdate, err := mongoManager.UpdateOne(collectionTest, timeoutTest, insertDocument, updateDocument)
-	expected := resultInsert
-	expected["test"] = "test2"
-	assert.NoError(t, err)
-	assert.Equal(t, expected, resultUpdate)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestUpdateOneAddFieldSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	resultInsert, err := mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-
-	updateDocument := map[string]interface{}{
-		"test2": "test2",
-	}
-	resultUpdate, err := mongoManager.UpdateOne(collectionTest, timeoutTest, insertDocument, updateDocument)
-	expected := resultInsert
-	expected["test2"] = "test2"
-	assert.NoError(t, err)
-	assert.Equal(t, expected, resultUpdate)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestUpdateOneFailedNoExist(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	result, err := mongoManager.UpdateOne(collectionTest, timeoutTest, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test"})
-	assert.Nil(t, result)
-	var myErr *libraryErrors.NotExistError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestUpdateOneFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	result, err := mongoManager.UpdateOne(collectionTest, 0, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test"})
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestUpdateOneFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -428,47 +143,6 @@ func TestUpdateOneFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestUpdateManySuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument := map[string]interface{}{
-		"document": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument)
-	insertDocuments = append(insertDocuments, insertDocument)
-	resultInsert, err := mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	assert.NoError(t, err)
-
-	updateDocument := map[string]interface{}{
-		"document": "test2",
-	}
-	resultUpdate, err := mongoManager.UpdateMany(collectionTest, timeoutTest, insertDocument, updateDocument)
-	assert.NoError(t, err)
-	for index, item := range resultInsert {
-		expected := item
-		expected["document"] = "test2"
-		assert.Equal(t, expected, resultUpdate[index])
-	}
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestUpdateManyFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	result, err := mongoManager.UpdateMany(collectionTest, 0, map[string]interface{}{"test": "test"}, map[string]interface{}{"test": "test"})
-	assert.Nil(t, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestUpdateManyFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -478,47 +152,6 @@ func TestUpdateManyFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestDeleteOneSuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	insertDocument := map[string]interface{}{
-		"test": "test",
-	}
-	_, err = mongoManager.InsertOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-
-	err = mongoManager.DeleteOne(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestDeleteOneFailedNoExist(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	err = mongoManager.DeleteOne(collectionTest, timeoutTest, map[string]interface{}{"test": "test"})
-	var myErr *libraryErrors.NotExistError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestDeleteOneFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	err = mongoManager.DeleteOne(collectionTest, 0, map[string]interface{}{"test": "test"})
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestDeleteOneFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
@@ -527,40 +160,6 @@ func TestDeleteOneFailedClientNotCreated(t *testing.T) {
 	assert.ErrorAs(t, err, &myErr)
 }
 
-func TestDeleteManySuccess(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	var insertDocuments []map[string]interface{}
-	insertDocument := map[string]interface{}{
-		"document": "test",
-	}
-	insertDocuments = append(insertDocuments, insertDocument)
-	insertDocuments = append(insertDocuments, insertDocument)
-	_, err = mongoManager.InsertMany(collectionTest, timeoutTest, insertDocuments)
-	assert.NoError(t, err)
-
-	result, err := mongoManager.DeleteMany(collectionTest, timeoutTest, insertDocument)
-	assert.NoError(t, err)
-	assert.Equal(t, 2, result)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
-func TestDeleteManyFailedInvalidTimeout(t *testing.T) {
-	mongoManager, err := initializeDb()
-	assert.NoError(t, err)
-
-	result, err := mongoManager.DeleteMany(collectionTest, 0, map[string]interface{}{"test": "test"})
-	assert.Equal(t, 0, result)
-	var myErr *libraryErrors.InputError
-	assert.ErrorAs(t, err, &myErr)
-
-	err = mongoManager.DisconnectDb()
-	assert.NoError(t, err)
-}
-
 func TestDeleteManyFailedClientNotCreated(t *testing.T) {
 	mongoManager := new(Manager)
 
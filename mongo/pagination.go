@@ -0,0 +1,234 @@
+package mongo
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/internal/bsonutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultPaginationKey signs the tokens of the Managers without WithPaginationKey. It is random, so their tokens
+// are not valid after a restart or in other instances of the service
+var defaultPaginationKey = randomKey()
+
+// WithPaginationKey defines the secret key that signs the tokens of Paginate. The instances of a service must
+// share it to accept the tokens created by the others
+func WithPaginationKey(key []byte) Option {
+	return func(config *Config) {
+		config.PaginationKey = key
+	}
+}
+
+// pageToken is the content of the tokens of Paginate
+// Database: It is the database of the page
+// Collection: It is the collection of the page
+// SortKey: It is the field that sorts the documents
+// Descending: It is the order of the documents
+// Backward: It is true for the tokens of the previous page
+// Value: It is the value of the sort key of the document at the edge of the page
+// ID: It is the _id of the document at the edge of the page
+type pageToken struct {
+	Database   string      `bson:"db"`
+	Collection string      `bson:"c"`
+	SortKey    string      `bson:"k"`
+	Descending bool        `bson:"d"`
+	Backward   bool        `bson:"b"`
+	Value      interface{} `bson:"v"`
+	ID         interface{} `bson:"i"`
+}
+
+// Paginate is the function inside the Manager to get a page of the documents sorted by a key, using the key of the
+// last document instead of an offset. It follows the database.Paginator interface
+// collection: Name of the collection to paginate
+// timeout: It is the time to define the timeout of the operation
+// filter: It is the filter of the documents
+// request: It defines the sort key, the order, the size of the page and the token of the page
+// It returns the page and an error. It is an InputError if the token is not valid for the request
+func (manager *Manager) Paginate(collection string, timeout int64, filter map[string]interface{}, request database.PageRequest) (database.Page, error) {
+	start := time.Now()
+	page, err := manager.paginate(collection, timeout, filter, request)
+	manager.logOperation(operationPaginate, collection, filter, start, len(page.Documents), err)
+	return page, err
+}
+
+// paginate contains the logic of Paginate, without logging
+func (manager *Manager) paginate(collection string, timeout int64, filter map[string]interface{}, request database.PageRequest) (database.Page, error) {
+	if timeout < 1 {
+		return database.Page{}, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
+	}
+	if request.Size < 1 {
+		return database.Page{}, &libraryErrors.InputError{Message: fmt.Sprintf(pageSizeMessage, request.Size)}
+	}
+	sortKey := request.SortKey
+	if sortKey == "" {
+		sortKey = idField
+	}
+	token := pageToken{Collection: collection, SortKey: sortKey, Descending: request.Descending}
+	if request.Token != "" {
+		decoded, err := manager.decodeToken(request.Token)
+		if err != nil {
+			return database.Page{}, err
+		}
+		if decoded.Collection != collection || decoded.SortKey != sortKey || decoded.Descending != request.Descending {
+			return database.Page{}, &libraryErrors.InputError{Message: invalidTokenMessage}
+		}
+		token = decoded
+	}
+
+	mongoCollection, ctx, release, err := manager.getCollection(collection, timeout)
+	if err != nil {
+		return database.Page{}, err
+	}
+	defer release()
+	// The token of a handle of another database is not valid, even for a collection with the same name
+	databaseName := mongoCollection.Database().Name()
+	if request.Token != "" && token.Database != databaseName {
+		return database.Page{}, &libraryErrors.InputError{Message: invalidTokenMessage}
+	}
+	token.Database = databaseName
+
+	// The previous page is read in the opposite order and reversed
+	descending := request.Descending != token.Backward
+	query := bson.M{}
+	for key, value := range filter {
+		query[key] = value
+	}
+	if request.Token != "" {
+		query = bson.M{"$and": bson.A{query, keysetFilter(sortKey, descending, token.Value, token.ID)}}
+	}
+	order := 1
+	if descending {
+		order = -1
+	}
+	sort := bson.D{{Key: sortKey, Value: order}}
+	if sortKey != idField {
+		sort = append(sort, bson.E{Key: idField, Value: order})
+	}
+
+	cursor, err := mongoCollection.Find(ctx, query, options.Find().SetSort(sort).SetLimit(request.Size+1))
+	if err != nil {
+		if _, ok := err.(mongo.CommandError); ok {
+			return database.Page{}, &libraryErrors.ConnectionError{Db: mongoDB}
+		}
+		return database.Page{}, err
+	}
+	var documents []map[string]interface{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return database.Page{}, err
+	}
+	more := int64(len(documents)) > request.Size
+	if more {
+		documents = documents[:request.Size]
+	}
+	if token.Backward {
+		slices.Reverse(documents)
+	}
+
+	page := database.Page{Documents: documents}
+	if len(documents) == 0 {
+		return page, nil
+	}
+	hasNext, hasPrevious := more, request.Token != ""
+	if token.Backward {
+		hasNext, hasPrevious = true, more
+	}
+	if hasNext {
+		if page.Next, err = manager.encodeToken(token, false, documents[len(documents)-1]); err != nil {
+			return database.Page{}, err
+		}
+	}
+	if hasPrevious {
+		if page.Previous, err = manager.encodeToken(token, true, documents[0]); err != nil {
+			return database.Page{}, err
+		}
+	}
+	return page, nil
+}
+
+// keysetFilter returns the filter of the documents after the value and the _id in the order of the sort. The
+// comparison operators do not match null, which sorts before the rest of the values, so the documents with a null
+// or missing sort key are matched with their own clauses
+func keysetFilter(sortKey string, descending bool, value, id interface{}) bson.M {
+	operator := "$gt"
+	if descending {
+		operator = "$lt"
+	}
+	if sortKey == idField {
+		return bson.M{idField: bson.M{operator: id}}
+	}
+	if value == nil {
+		clauses := bson.A{bson.M{sortKey: nil, idField: bson.M{operator: id}}}
+		if !descending {
+			clauses = append(clauses, bson.M{sortKey: bson.M{"$ne": nil}})
+		}
+		return bson.M{"$or": clauses}
+	}
+	clauses := bson.A{
+		bson.M{sortKey: bson.M{operator: value}},
+		bson.M{sortKey: value, idField: bson.M{operator: id}},
+	}
+	if descending {
+		clauses = append(clauses, bson.M{sortKey: nil})
+	}
+	return bson.M{"$or": clauses}
+}
+
+// encodeToken creates the signed token of the page next to the document
+func (manager *Manager) encodeToken(token pageToken, backward bool, document map[string]interface{}) (string, error) {
+	token.Backward = backward
+	token.Value, _ = bsonutil.GetField(document, token.SortKey)
+	token.ID = document[idField]
+	payload, err := bson.MarshalExtJSON(token, true, false)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(manager.sign(encoded)), nil
+}
+
+// decodeToken checks the signature of the token and returns its content
+func (manager *Manager) decodeToken(encoded string) (pageToken, error) {
+	var token pageToken
+	payload, signature, found := strings.Cut(encoded, ".")
+	if !found {
+		return token, &libraryErrors.InputError{Message: invalidTokenMessage}
+	}
+	decodedSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decodedSignature, manager.sign(payload)) {
+		return token, &libraryErrors.InputError{Message: invalidTokenMessage}
+	}
+	decodedPayload, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || bson.UnmarshalExtJSON(decodedPayload, true, &token) != nil {
+		return token, &libraryErrors.InputError{Message: invalidTokenMessage}
+	}
+	return token, nil
+}
+
+// sign returns the HMAC-SHA256 of the payload with the pagination key of the Manager
+func (manager *Manager) sign(payload string) []byte {
+	key := manager.config.PaginationKey
+	if len(key) == 0 {
+		key = defaultPaginationKey
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// randomKey returns a random key for the signatures
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
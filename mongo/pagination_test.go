@@ -0,0 +1,215 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/internal/bsonutil"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPaginateSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	for _, age := range []int{3, 1, 2, 2, 5} {
+		_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"age": age})
+		assert.NoError(t, err)
+	}
+	ages := func(page database.Page) []interface{} {
+		var result []interface{}
+		for _, document := range page.Documents {
+			result = append(result, document["age"])
+		}
+		return result
+	}
+	request := database.PageRequest{SortKey: "age", Size: 2}
+
+	first, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(1), int32(2)}, ages(first))
+	assert.Empty(t, first.Previous)
+
+	request.Token = first.Next
+	second, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(2), int32(3)}, ages(second))
+
+	request.Token = second.Next
+	last, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(5)}, ages(last))
+	assert.Empty(t, last.Next)
+
+	request.Token = second.Previous
+	previous, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Documents, previous.Documents)
+	assert.Empty(t, previous.Previous)
+
+	descending, err := mongoManager.Paginate(collectionTest, timeoutTest, map[string]interface{}{"age": map[string]interface{}{"$gt": 1}}, database.PageRequest{SortKey: "age", Descending: true, Size: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(5), int32(3), int32(2), int32(2)}, ages(descending))
+
+	request = database.PageRequest{SortKey: "name", Size: 2, Token: first.Next}
+	_, err = mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestPaginateDottedSortKey(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	for _, city := range []string{"Madrid", "Bilbao", "Cadiz"} {
+		_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"address": map[string]interface{}{"city": city}})
+		assert.NoError(t, err)
+	}
+	request := database.PageRequest{SortKey: "address.city", Size: 2}
+
+	first, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	assert.Len(t, first.Documents, 2)
+	request.Token = first.Next
+	second, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	if assert.Len(t, second.Documents, 1) {
+		city, _ := bsonutil.GetField(second.Documents[0], "address.city")
+		assert.Equal(t, "Madrid", city)
+	}
+
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestPaginateFailedOtherDatabase(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	var myErr *libraryErrors.InputError
+	tenant1, err := mongoManager.ForDatabase(dbTest + "_tenant1")
+	assert.NoError(t, err)
+	tenant2, err := mongoManager.ForDatabase(dbTest + "_tenant2")
+	assert.NoError(t, err)
+	for _, age := range []int{1, 2} {
+		_, err = tenant1.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"age": age})
+		assert.NoError(t, err)
+	}
+	request := database.PageRequest{SortKey: "age", Size: 1}
+
+	first, err := tenant1.(*Manager).Paginate(collectionTest, timeoutTest, nil, request)
+	assert.NoError(t, err)
+	request.Token = first.Next
+	_, err = tenant2.(*Manager).Paginate(collectionTest, timeoutTest, nil, request)
+	assert.ErrorAs(t, err, &myErr)
+
+	_, err = tenant1.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestPageTokenSuccess(t *testing.T) {
+	mongoManager := new(Manager)
+	id := primitive.NewObjectID()
+	token := pageToken{Database: "test", Collection: collectionTest, SortKey: "age"}
+
+	encoded, err := mongoManager.encodeToken(token, true, map[string]interface{}{"age": int32(1), "_id": id})
+	assert.NoError(t, err)
+	decoded, err := mongoManager.decodeToken(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, pageToken{Database: "test", Collection: collectionTest, SortKey: "age", Backward: true, Value: int32(1), ID: id}, decoded)
+
+	other := new(Manager)
+	assert.NoError(t, other.Configure(WithPaginationKey([]byte("key"))))
+	_, err = other.decodeToken(encoded)
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestPageTokenFailedTampered(t *testing.T) {
+	mongoManager := new(Manager)
+	var myErr *libraryErrors.InputError
+
+	encoded, err := mongoManager.encodeToken(pageToken{Collection: collectionTest, SortKey: "age"}, false, map[string]interface{}{"age": 1})
+	assert.NoError(t, err)
+	for _, token := range []string{"invalid", encoded + "x", "x" + encoded} {
+		_, err = mongoManager.decodeToken(token)
+		assert.ErrorAs(t, err, &myErr, token)
+	}
+}
+
+func TestPaginateFailedInvalidRequest(t *testing.T) {
+	mongoManager := new(Manager)
+	var myErr *libraryErrors.InputError
+
+	_, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, database.PageRequest{})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.Paginate(collectionTest, 0, nil, database.PageRequest{Size: 1})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.Paginate(collectionTest, timeoutTest, nil, database.PageRequest{Size: 1, Token: "invalid"})
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestKeysetFilter(t *testing.T) {
+	assert.Equal(t, bson.M{"_id": bson.M{"$gt": 1}}, keysetFilter("_id", false, nil, 1))
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"age": bson.M{"$lt": 2}},
+		bson.M{"age": 2, "_id": bson.M{"$lt": 1}},
+		bson.M{"age": nil},
+	}}, keysetFilter("age", true, 2, 1))
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"age": bson.M{"$gt": 2}},
+		bson.M{"age": 2, "_id": bson.M{"$gt": 1}},
+	}}, keysetFilter("age", false, 2, 1))
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"age": nil, "_id": bson.M{"$gt": 1}},
+		bson.M{"age": bson.M{"$ne": nil}},
+	}}, keysetFilter("age", false, nil, 1))
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"age": nil, "_id": bson.M{"$lt": 1}},
+	}}, keysetFilter("age", true, nil, 1))
+}
+
+func TestPaginateSparseSortKey(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	for _, document := range []map[string]interface{}{{"age": 2}, {"name": "missing"}, {"age": nil}, {"age": 1}} {
+		_, err = mongoManager.InsertOne(collectionTest, timeoutTest, document)
+		assert.NoError(t, err)
+	}
+
+	for _, descending := range []bool{false, true} {
+		request := database.PageRequest{SortKey: "age", Size: 1, Descending: descending}
+		var ages []interface{}
+		for i := 0; i < 10; i++ {
+			page, err := mongoManager.Paginate(collectionTest, timeoutTest, nil, request)
+			assert.NoError(t, err)
+			for _, document := range page.Documents {
+				ages = append(ages, document["age"])
+			}
+			if page.Next == "" {
+				break
+			}
+			request.Token = page.Next
+		}
+		assert.Len(t, ages, 4, descending)
+		if descending {
+			assert.Equal(t, []interface{}{int32(2), int32(1)}, ages[:2])
+		} else {
+			assert.Equal(t, []interface{}{int32(1), int32(2)}, ages[2:])
+		}
+	}
+
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
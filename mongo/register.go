@@ -0,0 +1,43 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+func init() {
+	database.Register(mongoScheme, open)
+	database.Register(mongoSRVScheme, open)
+}
+
+// open is the Factory of the Manager for database.Open. The name of the DB is the path of the URI and the
+// timeout is the remaining time of the deadline of the context (defaultOpenTimeout if it has no deadline)
+func open(ctx context.Context, uri string) (database.DatabaseInterface, error) {
+	connString, err := connstring.Parse(uri)
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: err.Error()}
+	}
+	if connString.Database == "" {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(databaseMissingMessage, uri)}
+	}
+
+	timeout := int64(defaultOpenTimeout)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = int64(math.Ceil(time.Until(deadline).Seconds()))
+		if timeout < 1 {
+			return nil, context.DeadlineExceeded
+		}
+	}
+
+	manager, err := CreateManager(uri, connString.Database, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
@@ -0,0 +1,31 @@
+package mongo
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenSuccess(t *testing.T) {
+	mongoURI := os.Getenv("Mongo_URI")
+	assert.NotEqual(t, "", mongoURI)
+
+	db, err := database.Open(context.Background(), mongoURI+"/"+dbTest)
+	assert.NoError(t, err)
+	assert.IsType(t, new(Manager), db)
+	assert.NoError(t, db.DisconnectDb())
+}
+
+func TestOpenFailedDatabaseNotDefined(t *testing.T) {
+	assert.Contains(t, database.Schemes(), mongoScheme)
+	assert.Contains(t, database.Schemes(), mongoSRVScheme)
+
+	db, err := database.Open(context.Background(), "mongodb://localhost:27017")
+	assert.Nil(t, db)
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
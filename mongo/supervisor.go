@@ -0,0 +1,218 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// ConnectionState is the state of the connection of the Manager
+type ConnectionState int
+
+const (
+	// StateClosed is the state before ConnectDb and after DisconnectDb
+	StateClosed ConnectionState = iota
+	// StateConnecting is the state while ConnectDb is running
+	StateConnecting
+	// StateConnected is the state when the MongoDB answers
+	StateConnected
+	// StateDegraded is the state when the supervision detects that the MongoDB does not answer. The Manager
+	// tries to reconnect until it succeeds or DisconnectDb is called
+	StateDegraded
+)
+
+// String returns the name of the state
+func (state ConnectionState) String() string {
+	switch state {
+	case StateClosed:
+		return "closed"
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateDegraded:
+		return "degraded"
+	default:
+		return fmt.Sprintf("unknown state: %d", int(state))
+	}
+}
+
+// SupervisionConfig is the structure to configure the supervision of the connection. The values that are 0 use
+// the default ones
+// CheckInterval: It is the time between the checks of the connection (10 seconds by default)
+// InitialBackoff: It is the time to wait after the first failed reconnection (1 second by default)
+// MaxBackoff: It is the maximum time to wait between reconnections. The time is doubled after each failed
+// reconnection until this value (1 minute by default)
+type SupervisionConfig struct {
+	CheckInterval  time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// WithSupervision enables the supervision of the connection. The Manager checks the connection periodically and
+// reconnects with backoff when it is lost, until DisconnectDb is called
+func WithSupervision(supervision SupervisionConfig) Option {
+	return func(config *Config) {
+		config.Supervision = &supervision
+	}
+}
+
+// State is the function inside the Manager to get the state of the connection
+// It returns the state of the connection
+func (manager *Manager) State() ConnectionState {
+	root := manager.owner()
+	root.mutex.RLock()
+	defer root.mutex.RUnlock()
+	return root.state
+}
+
+// OnStateChange is the function inside the Manager to add a function that is called every time the state of
+// the connection changes. The functions are called in the order they were added, from the goroutine that
+// changes the state, so they must not block
+// callback: It is the function to call with the previous and the new state
+func (manager *Manager) OnStateChange(callback func(from, to ConnectionState)) {
+	root := manager.owner()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	root.stateCallbacks = append(root.stateCallbacks, callback)
+}
+
+// setState changes the state of the connection and calls the callbacks if it is different
+func (manager *Manager) setState(state ConnectionState) {
+	manager.mutex.Lock()
+	previous := manager.state
+	manager.state = state
+	callbacks := manager.stateCallbacks
+	manager.mutex.Unlock()
+
+	if previous == state {
+		return
+	}
+	manager.getLogger().Debug(stateChangedMessage, slog.String("db", mongoDB), slog.String("from", previous.String()), slog.String("to", state.String()))
+	for _, callback := range callbacks {
+		callback(previous, state)
+	}
+}
+
+// startSupervisor starts the goroutine that supervises the connection, if it is not running. It must be called
+// with the mutex held
+func (manager *Manager) startSupervisor(supervision SupervisionConfig) {
+	if supervision.CheckInterval <= 0 {
+		supervision.CheckInterval = defaultCheckInterval
+	}
+	if supervision.InitialBackoff <= 0 {
+		supervision.InitialBackoff = defaultInitialBackoff
+	}
+	if supervision.MaxBackoff < supervision.InitialBackoff {
+		supervision.MaxBackoff = max(defaultMaxBackoff, supervision.InitialBackoff)
+	}
+
+	if manager.supervisorStop != nil {
+		return
+	}
+	manager.supervisorStop = make(chan struct{})
+	manager.supervisorDone = make(chan struct{})
+	go manager.supervise(supervision, manager.supervisorStop, manager.supervisorDone)
+}
+
+// stopSupervisor stops the goroutine that supervises the connection and waits until it finishes
+func (manager *Manager) stopSupervisor() {
+	manager.mutex.Lock()
+	stop, done := manager.supervisorStop, manager.supervisorDone
+	manager.supervisorStop, manager.supervisorDone = nil, nil
+	manager.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// supervise checks the connection every CheckInterval and reconnects with backoff when it is lost
+func (manager *Manager) supervise(supervision SupervisionConfig, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(supervision.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		if manager.ping() == nil {
+			continue
+		}
+
+		manager.setState(StateDegraded)
+		backoff := supervision.InitialBackoff
+		for manager.reconnect() != nil {
+			select {
+			case <-stop:
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, supervision.MaxBackoff)
+		}
+		manager.setState(StateConnected)
+	}
+}
+
+// ping checks the current client of the Manager
+func (manager *Manager) ping() error {
+	manager.mutex.RLock()
+	client, timeout := manager.client, manager.timeout
+	manager.mutex.RUnlock()
+	if client == nil {
+		return &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+	return client.Ping(ctx, nil)
+}
+
+// reconnect checks the current client again and, if it still fails, replaces it with a new client
+func (manager *Manager) reconnect() error {
+	if manager.ping() == nil {
+		return nil
+	}
+
+	manager.mutex.RLock()
+	if manager.client == nil || manager.shuttingDown {
+		manager.mutex.RUnlock()
+		return &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	dbURI, dbName, timeout := manager.dbURI, manager.database.Name(), manager.timeout
+	manager.mutex.RUnlock()
+
+	start := time.Now()
+	client, err := manager.newClient(dbURI, dbName, timeout)
+	if err != nil {
+		manager.logConnection(reconnectionFailedMessage, dbName, start, err)
+		return err
+	}
+
+	manager.mutex.Lock()
+	if manager.client == nil || manager.shuttingDown {
+		// Shutdown ran while the new client was created, so it is not kept
+		manager.mutex.Unlock()
+		_ = client.Disconnect(context.Background())
+		return &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	previous := manager.client
+	manager.client = client
+	manager.database = client.Database(dbName)
+	manager.mutex.Unlock()
+	manager.logConnection(reconnectedMessage, dbName, start, nil)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		defer cancel()
+		_ = previous.Disconnect(ctx)
+	}()
+	return nil
+}
@@ -0,0 +1,100 @@
+package mongo
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type stateRecorder struct {
+	mutex  sync.Mutex
+	states []ConnectionState
+}
+
+func (recorder *stateRecorder) record(from, to ConnectionState) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.states = append(recorder.states, to)
+}
+
+func (recorder *stateRecorder) get() []ConnectionState {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]ConnectionState(nil), recorder.states...)
+}
+
+func TestSupervisionReconnect(t *testing.T) {
+	mongoURI := os.Getenv("Mongo_URI")
+	assert.NotEqual(t, "", mongoURI)
+
+	recorder := new(stateRecorder)
+	mongoManager := new(Manager)
+	mongoManager.OnStateChange(recorder.record)
+	err := mongoManager.Configure(WithSupervision(SupervisionConfig{CheckInterval: 50 * time.Millisecond, InitialBackoff: 10 * time.Millisecond}))
+	assert.NoError(t, err)
+	err = mongoManager.ConnectDb(mongoURI, dbTest, timeoutTest)
+	assert.NoError(t, err)
+	assert.Equal(t, StateConnected, mongoManager.State())
+
+	lostClient := mongoManager.GetClient()
+	assert.NoError(t, lostClient.Disconnect(context.Background()))
+	assert.Eventually(t, func() bool {
+		return mongoManager.GetClient() != lostClient && mongoManager.State() == StateConnected
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+	assert.Equal(t, StateClosed, mongoManager.State())
+	assert.Equal(t, []ConnectionState{StateConnecting, StateConnected, StateDegraded, StateConnected, StateClosed}, recorder.get())
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestStateFailedConnection(t *testing.T) {
+	recorder := new(stateRecorder)
+	mongoManager := new(Manager)
+	mongoManager.OnStateChange(recorder.record)
+	assert.Equal(t, StateClosed, mongoManager.State())
+
+	err := mongoManager.ConnectDb("mongodb://test", dbTest, 1)
+	var myErr *libraryErrors.ConnectionError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, StateClosed, mongoManager.State())
+	assert.Equal(t, []ConnectionState{StateConnecting, StateClosed}, recorder.get())
+}
+
+func TestSupervisionFailedInvalidConfig(t *testing.T) {
+	mongoManager := new(Manager)
+
+	err := mongoManager.Configure(WithSupervision(SupervisionConfig{CheckInterval: -time.Second}))
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestReconnectFailedShutDown(t *testing.T) {
+	mongoManager := &Manager{dbURI: "mongodb://localhost:27017", timeout: timeoutTest}
+
+	err := mongoManager.reconnect()
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Nil(t, mongoManager.GetClient())
+}
+
+func TestConnectionStateString(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "connecting", StateConnecting.String())
+	assert.Equal(t, "connected", StateConnected.String())
+	assert.Equal(t, "degraded", StateDegraded.String())
+	assert.Equal(t, "unknown state: 10", ConnectionState(10).String())
+}
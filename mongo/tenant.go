@@ -0,0 +1,87 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// WithTenantDatabase defines how ForTenant maps a tenant to the name of its DB. By default, the name of the DB
+// is the tenant
+func WithTenantDatabase(mapping func(tenant string) string) Option {
+	return func(config *Config) {
+		config.TenantDatabase = mapping
+	}
+}
+
+// ForDatabase is the function inside the Manager to get a handle to another DB of the MongoDB that shares the
+// client of the Manager. The operations of the handle only reach that DB, and ConnectDb, DisconnectDb and Shutdown
+// do not change the shared client. The handles are cached, so it can be called for every request
+// name: It is the name of the DB
+// It returns the handle and an InputError if the name of the DB is not valid
+func (manager *Manager) ForDatabase(name string) (database.DatabaseInterface, error) {
+	if err := validateDatabaseName(name); err != nil {
+		return nil, err
+	}
+
+	root := manager.owner()
+	root.mutex.Lock()
+	defer root.mutex.Unlock()
+	if handle, found := manager.databases[name]; found {
+		return handle, nil
+	}
+	handle := &Manager{
+		root:               root,
+		databaseName:       name,
+		logger:             manager.logger,
+		slowQueryThreshold: manager.slowQueryThreshold,
+		redaction:          manager.redaction,
+		redactedKeys:       manager.redactedKeys,
+		config:             manager.config,
+		collectionOptions:  manager.collectionOptions,
+	}
+	if manager.databases == nil {
+		manager.databases = make(map[string]*Manager)
+	}
+	manager.databases[name] = handle
+	return handle, nil
+}
+
+// ForTenant is the function inside the Manager to get the handle of the DB of the tenant carried by the context
+// (see database.ContextWithTenant). The name of the DB is defined with WithTenantDatabase
+// ctx: It is the context with the tenant
+// It returns the handle and an InputError if the context does not carry a tenant or the name of the DB is not valid
+// or it is reserved (admin, local or config)
+func (manager *Manager) ForTenant(ctx context.Context) (database.DatabaseInterface, error) {
+	tenant, ok := database.TenantFromContext(ctx)
+	if !ok {
+		return nil, &libraryErrors.InputError{Message: tenantMissingMessage}
+	}
+	name := tenant
+	if manager.config.TenantDatabase != nil {
+		name = manager.config.TenantDatabase(tenant)
+	}
+	if reservedDatabases[strings.ToLower(name)] {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(reservedDatabaseMessage, name)}
+	}
+	return manager.ForDatabase(name)
+}
+
+// reservedDatabases are the DBs of the MongoDB itself, that ForTenant never returns
+var reservedDatabases = map[string]bool{
+	"admin":  true,
+	"local":  true,
+	"config": true,
+}
+
+// validateDatabaseName checks the restrictions of MongoDB for the names of the DBs, so a name like "a.b" or
+// "../admin" can not reach another DB. The reserved DBs are rejected by ForTenant
+func validateDatabaseName(name string) error {
+	if name == "" || len(name) > maxDatabaseNameLength || strings.ContainsAny(name, invalidDatabaseCharacters) {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(databaseNameMessage, name)}
+	}
+	return nil
+}
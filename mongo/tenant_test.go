@@ -0,0 +1,117 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForDatabaseSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	tenant1, err := mongoManager.ForDatabase(dbTest + "_tenant1")
+	assert.NoError(t, err)
+	tenant2, err := mongoManager.ForDatabase(dbTest + "_tenant2")
+	assert.NoError(t, err)
+	_, _ = tenant1.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	_, _ = tenant2.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+
+	_, err = tenant1.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"name": "tenant1"})
+	assert.NoError(t, err)
+	result, err := tenant2.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	result, err = mongoManager.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	assert.NoError(t, tenant1.DisconnectDb())
+	result, err = tenant1.FindMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	_, err = tenant1.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestForDatabaseCached(t *testing.T) {
+	mongoManager := new(Manager)
+
+	handle, err := mongoManager.ForDatabase("tenant1")
+	assert.NoError(t, err)
+	cached, err := mongoManager.ForDatabase("tenant1")
+	assert.NoError(t, err)
+	assert.Same(t, handle, cached)
+	other, err := mongoManager.ForDatabase("tenant2")
+	assert.NoError(t, err)
+	assert.NotSame(t, handle, other)
+}
+
+func TestForDatabaseFailedInvalidName(t *testing.T) {
+	mongoManager := new(Manager)
+
+	for _, name := range []string{"", "a.b", "../admin", "a b", "a$b", "a/b", string(make([]byte, 64))} {
+		_, err := mongoManager.ForDatabase(name)
+		var myErr *libraryErrors.InputError
+		assert.ErrorAs(t, err, &myErr, name)
+	}
+}
+
+func TestForDatabaseSharedClient(t *testing.T) {
+	mongoManager := new(Manager)
+	handle, err := mongoManager.ForDatabase("tenant1")
+	assert.NoError(t, err)
+
+	err = handle.ConnectDb("mongodb://test", "tenant1", 1)
+	var clientError *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &clientError)
+	assert.NoError(t, handle.DisconnectDb())
+
+	_, err = handle.FindOne(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.ErrorAs(t, err, &clientError)
+}
+
+func TestForTenantSuccess(t *testing.T) {
+	mongoManager := new(Manager)
+	err := mongoManager.Configure(WithTenantDatabase(func(tenant string) string {
+		return "tenant_" + tenant
+	}))
+	assert.NoError(t, err)
+
+	handle, err := mongoManager.ForTenant(database.ContextWithTenant(context.Background(), "1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant_1", handle.(*Manager).databaseName)
+}
+
+func TestForTenantFailedReservedDatabase(t *testing.T) {
+	mongoManager := new(Manager)
+
+	for _, tenant := range []string{"admin", "local", "config", "ADMIN", "Config"} {
+		handle, err := mongoManager.ForTenant(database.ContextWithTenant(context.Background(), tenant))
+		assert.Nil(t, handle)
+		var myErr *libraryErrors.InputError
+		assert.ErrorAs(t, err, &myErr, tenant)
+	}
+
+	err := mongoManager.Configure(WithTenantDatabase(func(tenant string) string {
+		return "admin"
+	}))
+	assert.NoError(t, err)
+	_, err = mongoManager.ForTenant(database.ContextWithTenant(context.Background(), "1"))
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestForTenantFailedMissingTenant(t *testing.T) {
+	mongoManager := new(Manager)
+
+	_, err := mongoManager.ForTenant(context.Background())
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+}
@@ -0,0 +1,82 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetValidator is the function inside the Manager to make the MongoDB reject the documents of the collection that
+// do not follow a JSON Schema, also when they are written by other clients. The collection is created if it does
+// not exist. The type integer is converted to the BSON types int and long, which $jsonSchema requires, and _id is
+// allowed when additionalProperties is false
+// collection: Name of the collection
+// timeout: It is the time to define the timeout of the operation
+// jsonSchema: It is the JSON Schema, for example, the Map of a schema.Schema
+// It returns an error
+func (manager *Manager) SetValidator(collection string, timeout int64, jsonSchema map[string]interface{}) error {
+	if timeout < 1 {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, timeout)}
+	}
+	_, mongoDatabase, operations, err := manager.acquire()
+	if err != nil {
+		return err
+	}
+	defer manager.owner().release()
+	ctx, cancel := context.WithTimeout(operations, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	converted := mongoSchema(jsonSchema)
+	if properties, ok := converted["properties"].(map[string]interface{}); ok && converted["additionalProperties"] == false {
+		if _, found := properties[idField]; !found {
+			properties[idField] = map[string]interface{}{}
+		}
+	}
+	validator := bson.M{"$jsonSchema": converted}
+	err = mongoDatabase.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "strict"},
+	}).Err()
+	var commandError mongo.CommandError
+	if errors.As(err, &commandError) && commandError.Code == namespaceNotFoundCode {
+		err = mongoDatabase.CreateCollection(ctx, collection, options.CreateCollection().SetValidator(validator))
+	}
+	if errors.As(err, &commandError) {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(validatorMessage, collection, commandError.Message)}
+	}
+	return err
+}
+
+// mongoSchema returns a copy of the JSON Schema with the type integer converted to bsonType
+func mongoSchema(jsonSchema map[string]interface{}) map[string]interface{} {
+	converted := make(map[string]interface{}, len(jsonSchema))
+	for key, value := range jsonSchema {
+		switch {
+		case key == "type" && value == "integer":
+			converted["bsonType"] = bson.A{"int", "long"}
+		case key == "properties":
+			properties, _ := value.(map[string]interface{})
+			convertedProperties := make(map[string]interface{}, len(properties))
+			for name, property := range properties {
+				if propertySchema, ok := property.(map[string]interface{}); ok {
+					convertedProperties[name] = mongoSchema(propertySchema)
+				}
+			}
+			converted[key] = convertedProperties
+		case key == "items":
+			if items, ok := value.(map[string]interface{}); ok {
+				converted[key] = mongoSchema(items)
+			}
+		default:
+			converted[key] = value
+		}
+	}
+	return converted
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"testing"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSetValidatorSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+
+	err = mongoManager.SetValidator(collectionTest, timeoutTest, map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"age"},
+		"properties": map[string]interface{}{"age": map[string]interface{}{"type": "integer"}},
+	})
+	assert.NoError(t, err)
+	_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"age": 1})
+	assert.NoError(t, err)
+	_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"age": "1"})
+	assert.Error(t, err)
+
+	err = mongoManager.SetValidator(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestSetValidatorFailedClientNotCreated(t *testing.T) {
+	mongoManager := new(Manager)
+
+	err := mongoManager.SetValidator(collectionTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestMongoSchema(t *testing.T) {
+	converted := mongoSchema(map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"age":  map[string]interface{}{"type": "integer"},
+			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}},
+		},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"age":  map[string]interface{}{"bsonType": bson.A{"int", "long"}},
+			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"bsonType": bson.A{"int", "long"}}},
+		},
+	}, converted)
+}
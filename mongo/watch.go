@@ -0,0 +1,352 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeStream is the implementation of database.ChangeStream over a change stream of the driver. It reopens the
+// stream from the last resume token after the transient errors, including the reconnections of the Manager
+// manager: It is the Manager that opened the stream
+// table: It is the name of the collection
+// pipeline: It is the pipeline with the filter of the stream
+// mutex: It serializes Next and Close
+// stream: It is the current change stream of the driver. It is nil after a failure until it is reopened
+// tokenMutex: It protects token
+// token: It is the resume token of the last event
+// closeCtx: It is cancelled by Close to stop a blocked Next
+type changeStream struct {
+	manager    *Manager
+	table      string
+	pipeline   mongo.Pipeline
+	mutex      sync.Mutex
+	stream     *mongo.ChangeStream
+	tokenMutex sync.Mutex
+	token      []byte
+	closed     bool
+	closeCtx   context.Context
+	closeFn    context.CancelFunc
+}
+
+// changeDocument is the structure of the events of the change streams of the MongoDB
+type changeDocument struct {
+	OperationType     string                 `bson:"operationType"`
+	Namespace         changeNamespace        `bson:"ns"`
+	DocumentKey       map[string]interface{} `bson:"documentKey"`
+	FullDocument      map[string]interface{} `bson:"fullDocument"`
+	UpdateDescription changeUpdate           `bson:"updateDescription"`
+	ClusterTime       primitive.Timestamp    `bson:"clusterTime"`
+}
+
+// changeNamespace is the namespace of a changeDocument
+type changeNamespace struct {
+	Collection string `bson:"coll"`
+}
+
+// changeUpdate is the description of an update in a changeDocument
+type changeUpdate struct {
+	UpdatedFields map[string]interface{} `bson:"updatedFields"`
+	RemovedFields []string               `bson:"removedFields"`
+}
+
+// Watch is the function inside the Manager to open a change stream with the changes of the collection from now on.
+// It requires a replica set or a sharded cluster. It follows the database.Watcher interface
+// ctx: It is the context to open the stream. Next receives its own context
+// collection: Name of the collection to watch
+// filter: It is the filter on the fields of the documents. The deletions are always delivered
+// It returns the stream and an error
+func (manager *Manager) Watch(ctx context.Context, collection string, filter map[string]interface{}) (database.ChangeStream, error) {
+	return manager.WatchFrom(ctx, collection, filter, nil)
+}
+
+// WatchFrom is the function inside the Manager to open a change stream with the changes of the collection after
+// the resume token of a previous event
+// ctx: It is the context to open the stream. Next receives its own context
+// collection: Name of the collection to watch
+// filter: It is the filter on the fields of the documents. The deletions are always delivered
+// resumeToken: It is the ResumeToken of the last event processed. If it is empty, the stream starts now
+// It returns the stream and an error
+func (manager *Manager) WatchFrom(ctx context.Context, collection string, filter map[string]interface{}, resumeToken []byte) (database.ChangeStream, error) {
+	closeCtx, closeFn := context.WithCancel(context.Background())
+	stream := &changeStream{
+		manager:  manager,
+		table:    collection,
+		pipeline: watchPipeline(filter),
+		token:    resumeToken,
+		closeCtx: closeCtx,
+		closeFn:  closeFn,
+	}
+	if err := stream.open(ctx); err != nil {
+		closeFn()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Next returns the next event of the stream. It blocks until there is an event, ctx is done, the stream is
+// closed or it fails with an error that can not be resumed
+func (stream *changeStream) Next(ctx context.Context) (database.ChangeEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(stream.closeCtx, cancel)
+	defer stop()
+
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	backoff := resumeInitialBackoff
+	for attempts := 0; ; attempts++ {
+		if stream.closed {
+			return database.ChangeEvent{}, &libraryErrors.ClientError{Message: streamClosedMessage}
+		}
+		if stream.stream != nil && stream.stream.Next(ctx) {
+			return stream.decode()
+		}
+		if stream.closeCtx.Err() != nil {
+			return database.ChangeEvent{}, &libraryErrors.ClientError{Message: streamClosedMessage}
+		}
+		if err := ctx.Err(); err != nil {
+			return database.ChangeEvent{}, err
+		}
+
+		err := &libraryErrors.ClientError{Message: streamClosedMessage}
+		if stream.stream != nil {
+			if streamErr := stream.stream.Err(); streamErr != nil {
+				err = nil
+				if !resumable(streamErr) {
+					return database.ChangeEvent{}, streamErr
+				}
+			}
+			_ = stream.stream.Close(context.Background())
+			stream.stream = nil
+			if err != nil {
+				return database.ChangeEvent{}, err
+			}
+		}
+		if attempts >= maxResumeAttempts {
+			return database.ChangeEvent{}, &libraryErrors.ConnectionError{Db: mongoDB}
+		}
+
+		select {
+		case <-ctx.Done():
+			continue
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, resumeMaxBackoff)
+		openErr := stream.open(ctx)
+		if openErr != nil && !resumable(openErr) {
+			return database.ChangeEvent{}, openErr
+		}
+		if openErr == nil {
+			stream.manager.getLogger().Debug(streamResumedMessage, slog.String("db", mongoDB), slog.String("collection", stream.table), slog.Int("attempts", attempts+1))
+		}
+	}
+}
+
+// ResumeToken returns the token of the last event returned by Next, or the token used to open the stream
+func (stream *changeStream) ResumeToken() []byte {
+	stream.tokenMutex.Lock()
+	defer stream.tokenMutex.Unlock()
+	return stream.token
+}
+
+// Close stops the stream. A blocked Next returns a ClientError
+func (stream *changeStream) Close() error {
+	stream.closeFn()
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	stream.closed = true
+	if stream.stream == nil {
+		return nil
+	}
+	err := stream.stream.Close(context.Background())
+	stream.stream = nil
+	return err
+}
+
+// open opens the change stream of the driver after the last resume token
+func (stream *changeStream) open(ctx context.Context) error {
+	collection, err := stream.manager.watchCollection(stream.table)
+	if err != nil {
+		return err
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token := stream.ResumeToken(); len(token) > 0 {
+		opts.SetResumeAfter(bson.Raw(token))
+	}
+	driverStream, err := collection.Watch(ctx, stream.pipeline, opts)
+	if err != nil {
+		var commandError mongo.CommandError
+		if errors.As(err, &commandError) && !resumable(err) {
+			return &libraryErrors.ConnectionError{Db: mongoDB}
+		}
+		return err
+	}
+	stream.stream = driverStream
+	return nil
+}
+
+// decode converts the current event of the driver to a ChangeEvent and saves its resume token
+func (stream *changeStream) decode() (database.ChangeEvent, error) {
+	var document changeDocument
+	if err := stream.stream.Decode(&document); err != nil {
+		return database.ChangeEvent{}, err
+	}
+	token := append([]byte(nil), stream.stream.ResumeToken()...)
+	stream.tokenMutex.Lock()
+	stream.token = token
+	stream.tokenMutex.Unlock()
+
+	return database.ChangeEvent{
+		Type:          database.ChangeType(document.OperationType),
+		Table:         document.Namespace.Collection,
+		DocumentKey:   document.DocumentKey,
+		FullDocument:  document.FullDocument,
+		UpdatedFields: document.UpdateDescription.UpdatedFields,
+		RemovedFields: document.UpdateDescription.RemovedFields,
+		ResumeToken:   token,
+		Time:          time.Unix(int64(document.ClusterTime.T), 0),
+	}, nil
+}
+
+// watchCollection returns the collection for a change stream. Unlike getCollection, the stream is not counted as
+// in-flight, because it is open until it is closed
+func (manager *Manager) watchCollection(name string) (*mongo.Collection, error) {
+	root := manager.owner()
+	root.mutex.RLock()
+	client, mongoDatabase, shuttingDown := root.client, root.database, root.shuttingDown
+	root.mutex.RUnlock()
+	if shuttingDown {
+		return nil, &libraryErrors.ShutdownError{Message: shuttingDownMessage}
+	}
+	if client == nil {
+		return nil, &libraryErrors.ClientError{Message: clientNotConnected}
+	}
+	if manager.databaseName != "" {
+		mongoDatabase = client.Database(manager.databaseName)
+	}
+	if manager.collectionOptions == nil {
+		return mongoDatabase.Collection(name), nil
+	}
+	return mongoDatabase.Collection(name, manager.collectionOptions), nil
+}
+
+// watchPipeline creates the pipeline of a change stream with the kinds of changes of database.ChangeEvent and the
+// filter on the full document
+func watchPipeline(filter map[string]interface{}) mongo.Pipeline {
+	match := bson.M{"operationType": bson.M{"$in": bson.A{
+		string(database.ChangeInsert), string(database.ChangeUpdate), string(database.ChangeReplace), string(database.ChangeDelete),
+	}}}
+	if len(filter) > 0 {
+		match["$or"] = bson.A{bson.M{"operationType": string(database.ChangeDelete)}, documentFilter(filter)}
+	}
+	return mongo.Pipeline{{{Key: "$match", Value: match}}}
+}
+
+// documentFilter moves a filter of documents to the full document of the change events. The fields are prefixed
+// with fullDocument, the logical operators ($and, $or, $nor) are rewritten recursively and the field paths of
+// $expr ("$field") point to the full document. The rest of operators are kept
+func documentFilter(filter map[string]interface{}) bson.M {
+	result := bson.M{}
+	for key, value := range filter {
+		switch {
+		case key == "$and" || key == "$or" || key == "$nor":
+			result[key] = logicalFilters(value)
+		case key == "$expr":
+			result[key] = expressionPaths(value)
+		case strings.HasPrefix(key, "$"):
+			result[key] = value
+		default:
+			result[fullDocumentPrefix+key] = value
+		}
+	}
+	return result
+}
+
+// logicalFilters rewrites the list of filters of a logical operator with documentFilter
+func logicalFilters(value interface{}) interface{} {
+	var filters []interface{}
+	switch typed := value.(type) {
+	case []interface{}:
+		filters = typed
+	case bson.A:
+		filters = typed
+	case []map[string]interface{}:
+		for _, filter := range typed {
+			filters = append(filters, filter)
+		}
+	case []bson.M:
+		for _, filter := range typed {
+			filters = append(filters, filter)
+		}
+	default:
+		return value
+	}
+
+	result := make(bson.A, len(filters))
+	for i, filter := range filters {
+		switch typed := filter.(type) {
+		case map[string]interface{}:
+			result[i] = documentFilter(typed)
+		case bson.M:
+			result[i] = documentFilter(typed)
+		default:
+			result[i] = filter
+		}
+	}
+	return result
+}
+
+// expressionPaths rewrites the field paths of an aggregation expression ("$field", but not the variables
+// "$$name") to the full document
+func expressionPaths(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case string:
+		if strings.HasPrefix(typed, "$") && !strings.HasPrefix(typed, "$$") {
+			return "$" + fullDocumentPrefix + typed[1:]
+		}
+		return typed
+	case map[string]interface{}:
+		result := make(bson.M, len(typed))
+		for key, item := range typed {
+			result[key] = expressionPaths(item)
+		}
+		return result
+	case bson.M:
+		return expressionPaths(map[string]interface{}(typed))
+	case []interface{}:
+		result := make(bson.A, len(typed))
+		for i, item := range typed {
+			result[i] = expressionPaths(item)
+		}
+		return result
+	case bson.A:
+		return expressionPaths([]interface{}(typed))
+	default:
+		return value
+	}
+}
+
+// resumable reports if the change stream can be reopened after the error
+func resumable(err error) bool {
+	var clientError *libraryErrors.ClientError
+	var serverError mongo.ServerError
+	switch {
+	case errors.As(err, &clientError), errors.Is(err, mongo.ErrClientDisconnected), mongo.IsNetworkError(err):
+		return true
+	case errors.As(err, &serverError):
+		return serverError.HasErrorLabel(resumableErrorLabel)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWatchSuccess(t *testing.T) {
+	mongoManager, err := initializeDb()
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := mongoManager.Watch(ctx, collectionTest, map[string]interface{}{"name": "watched"})
+	assert.NoError(t, err)
+	_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"name": "ignored"})
+	assert.NoError(t, err)
+	_, err = mongoManager.InsertOne(collectionTest, timeoutTest, map[string]interface{}{"name": "watched"})
+	assert.NoError(t, err)
+	_, err = mongoManager.UpdateOne(collectionTest, timeoutTest, map[string]interface{}{"name": "watched"}, map[string]interface{}{"age": 1})
+	assert.NoError(t, err)
+
+	event, err := stream.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, database.ChangeInsert, event.Type)
+	assert.Equal(t, collectionTest, event.Table)
+	assert.Equal(t, "watched", event.FullDocument["name"])
+	assert.NotEmpty(t, event.ResumeToken)
+	assert.Equal(t, event.ResumeToken, stream.ResumeToken())
+	assert.NoError(t, stream.Close())
+
+	resumed, err := mongoManager.WatchFrom(ctx, collectionTest, nil, event.ResumeToken)
+	assert.NoError(t, err)
+	event, err = resumed.Next(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, database.ChangeUpdate, event.Type)
+	assert.Equal(t, map[string]interface{}{"age": int32(1)}, event.UpdatedFields)
+	assert.NoError(t, resumed.Close())
+
+	_, err = resumed.Next(ctx)
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+	_, err = mongoManager.DeleteMany(collectionTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	err = mongoManager.DisconnectDb()
+	assert.NoError(t, err)
+}
+
+func TestWatchFailedClientNotCreated(t *testing.T) {
+	mongoManager := new(Manager)
+
+	_, err := mongoManager.Watch(context.Background(), collectionTest, nil)
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestWatchPipeline(t *testing.T) {
+	changeTypes := bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}
+	assert.Equal(t, mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": changeTypes}}}}, watchPipeline(nil))
+
+	expected := bson.M{
+		"operationType": changeTypes,
+		"$or":           bson.A{bson.M{"operationType": "delete"}, bson.M{"fullDocument.name": "test"}},
+	}
+	assert.Equal(t, mongo.Pipeline{{{Key: "$match", Value: expected}}}, watchPipeline(map[string]interface{}{"name": "test"}))
+}
+
+func TestDocumentFilterOperators(t *testing.T) {
+	filter := map[string]interface{}{
+		"$or": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"$and": []map[string]interface{}{{"age": bson.M{"$gt": 1}}}},
+		},
+		"$expr":    bson.M{"$gt": bson.A{"$total", "$$limit"}},
+		"$comment": "test",
+	}
+	expected := bson.M{
+		"$or": bson.A{
+			bson.M{"fullDocument.name": "a"},
+			bson.M{"$and": bson.A{bson.M{"fullDocument.age": bson.M{"$gt": 1}}}},
+		},
+		"$expr":    bson.M{"$gt": bson.A{"$fullDocument.total", "$$limit"}},
+		"$comment": "test",
+	}
+	assert.Equal(t, expected, documentFilter(filter))
+}
+
+func TestResumable(t *testing.T) {
+	assert.True(t, resumable(&libraryErrors.ClientError{Message: clientNotConnected}))
+	assert.True(t, resumable(mongo.ErrClientDisconnected))
+	assert.True(t, resumable(mongo.CommandError{Labels: []string{resumableErrorLabel}}))
+	assert.False(t, resumable(mongo.CommandError{Code: 40573}))
+	assert.False(t, resumable(context.Canceled))
+}
@@ -0,0 +1,149 @@
+package prommetrics
+
+import (
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Collector is the structure implementing the prometheus.Collector with the metrics of the operations and the
+// connection pool
+// requests: It is the number of operations per collection and operation
+// errors: It is the number of operations that failed per collection, operation and type of error
+// duration: It is the histogram with the duration of the operations per collection and operation
+// poolOpen: It is the number of connections open in the pool per server
+// poolInUse: It is the number of connections checked out from the pool per server
+// poolCheckoutFailures: It is the number of times a connection could not be checked out per server and reason
+// poolCleared: It is the number of times the pool was cleared per server
+type Collector struct {
+	requests             *prometheus.CounterVec
+	errors               *prometheus.CounterVec
+	duration             *prometheus.HistogramVec
+	poolOpen             *prometheus.GaugeVec
+	poolInUse            *prometheus.GaugeVec
+	poolCheckoutFailures *prometheus.CounterVec
+	poolCleared          *prometheus.CounterVec
+}
+
+// CreateCollector is the constructor for the Collector. It must be registered in a prometheus.Registerer
+// namespace: It is the namespace of the metrics. If it is empty, "dbclient" is used
+// buckets: They are the buckets of the duration histogram. If it is empty, prometheus.DefBuckets is used
+// It returns the Collector instance
+func CreateCollector(namespace string, buckets []float64) *Collector {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of operations sent to the database",
+		}, []string{collectionLabel, operationLabel}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of operations that failed, per type of error",
+		}, []string{collectionLabel, operationLabel, typeLabel}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the operations sent to the database",
+			Buckets:   buckets,
+		}, []string{collectionLabel, operationLabel}),
+		poolOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pool_connections_open",
+			Help:      "Number of connections open in the pool",
+		}, []string{addressLabel}),
+		poolInUse: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pool_connections_in_use",
+			Help:      "Number of connections checked out from the pool",
+		}, []string{addressLabel}),
+		poolCheckoutFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pool_checkout_failures_total",
+			Help:      "Number of times a connection could not be checked out from the pool",
+		}, []string{addressLabel, reasonLabel}),
+		poolCleared: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pool_cleared_total",
+			Help:      "Number of times the pool was cleared",
+		}, []string{addressLabel}),
+	}
+}
+
+// Wrap creates a Chain around the database that records the metrics of every operation
+// db: It is the database to measure
+// It returns the Chain
+func (collector *Collector) Wrap(db database.DatabaseInterface) *middleware.Chain {
+	return middleware.CreateChain(db, collector.Middleware())
+}
+
+// Middleware returns the Middleware that records the metrics of every operation
+func (collector *Collector) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(operation *middleware.Operation) {
+			next(operation)
+
+			table := operation.Table
+			if operation.Name == middleware.OperationConnectDb {
+				table = ""
+			}
+			collector.requests.WithLabelValues(table, operation.Name).Inc()
+			collector.duration.WithLabelValues(table, operation.Name).Observe(operation.Duration.Seconds())
+			if operation.Err != nil {
+				collector.errors.WithLabelValues(table, operation.Name, libraryErrors.Type(operation.Err)).Inc()
+			}
+		}
+	}
+}
+
+// PoolMonitor returns the monitor to add to the MongoDB client to update the metrics of the connection pool
+func (collector *Collector) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(poolEvent *event.PoolEvent) {
+			switch poolEvent.Type {
+			case event.ConnectionCreated:
+				collector.poolOpen.WithLabelValues(poolEvent.Address).Inc()
+			case event.ConnectionClosed:
+				collector.poolOpen.WithLabelValues(poolEvent.Address).Dec()
+			case event.GetSucceeded:
+				collector.poolInUse.WithLabelValues(poolEvent.Address).Inc()
+			case event.ConnectionReturned:
+				collector.poolInUse.WithLabelValues(poolEvent.Address).Dec()
+			case event.GetFailed:
+				collector.poolCheckoutFailures.WithLabelValues(poolEvent.Address, poolEvent.Reason).Inc()
+			case event.PoolCleared:
+				collector.poolCleared.WithLabelValues(poolEvent.Address).Inc()
+			}
+		},
+	}
+}
+
+// Describe is the function of the prometheus.Collector to send the descriptions of the metrics
+func (collector *Collector) Describe(descriptions chan<- *prometheus.Desc) {
+	collector.requests.Describe(descriptions)
+	collector.errors.Describe(descriptions)
+	collector.duration.Describe(descriptions)
+	collector.poolOpen.Describe(descriptions)
+	collector.poolInUse.Describe(descriptions)
+	collector.poolCheckoutFailures.Describe(descriptions)
+	collector.poolCleared.Describe(descriptions)
+}
+
+// Collect is the function of the prometheus.Collector to send the values of the metrics
+func (collector *Collector) Collect(metrics chan<- prometheus.Metric) {
+	collector.requests.Collect(metrics)
+	collector.errors.Collect(metrics)
+	collector.duration.Collect(metrics)
+	collector.poolOpen.Collect(metrics)
+	collector.poolInUse.Collect(metrics)
+	collector.poolCheckoutFailures.Collect(metrics)
+	collector.poolCleared.Collect(metrics)
+}
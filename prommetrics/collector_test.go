@@ -0,0 +1,86 @@
+package prommetrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "test"
+	addressTest = "localhost:27017"
+)
+
+func initializeCollector(t *testing.T) (*Collector, *prometheus.Registry) {
+	collector := CreateCollector("", nil)
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(collector))
+	return collector, registry
+}
+
+func TestCollectorOperations(t *testing.T) {
+	collector, registry := initializeCollector(t)
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			return nil, &libraryErrors.NotExistError{Message: "test"}
+		},
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			return []map[string]interface{}{}, nil
+		},
+	}
+	db := collector.Wrap(mock)
+
+	_, err := db.FindOne(tableTest, timeoutTest, nil)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+	_, err = db.FindMany(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	_, err = db.FindMany(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+
+	expected := `
+# HELP dbclient_errors_total Number of operations that failed, per type of error
+# TYPE dbclient_errors_total counter
+dbclient_errors_total{collection="test",operation="FindOne",type="NotExistError"} 1
+# HELP dbclient_requests_total Number of operations sent to the database
+# TYPE dbclient_requests_total counter
+dbclient_requests_total{collection="test",operation="FindMany"} 2
+dbclient_requests_total{collection="test",operation="FindOne"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "dbclient_requests_total", "dbclient_errors_total"))
+	assert.Equal(t, 2, testutil.CollectAndCount(collector.duration))
+}
+
+func TestCollectorPoolMonitor(t *testing.T) {
+	collector, registry := initializeCollector(t)
+	monitor := collector.PoolMonitor()
+
+	for _, eventType := range []string{event.ConnectionCreated, event.ConnectionCreated, event.GetSucceeded, event.GetSucceeded, event.ConnectionReturned, event.ConnectionClosed, event.PoolCleared} {
+		monitor.Event(&event.PoolEvent{Type: eventType, Address: addressTest})
+	}
+	monitor.Event(&event.PoolEvent{Type: event.GetFailed, Address: addressTest, Reason: event.ReasonTimedOut})
+
+	expected := `
+# HELP dbclient_pool_checkout_failures_total Number of times a connection could not be checked out from the pool
+# TYPE dbclient_pool_checkout_failures_total counter
+dbclient_pool_checkout_failures_total{address="localhost:27017",reason="timeout"} 1
+# HELP dbclient_pool_cleared_total Number of times the pool was cleared
+# TYPE dbclient_pool_cleared_total counter
+dbclient_pool_cleared_total{address="localhost:27017"} 1
+# HELP dbclient_pool_connections_in_use Number of connections checked out from the pool
+# TYPE dbclient_pool_connections_in_use gauge
+dbclient_pool_connections_in_use{address="localhost:27017"} 1
+# HELP dbclient_pool_connections_open Number of connections open in the pool
+# TYPE dbclient_pool_connections_open gauge
+dbclient_pool_connections_open{address="localhost:27017"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"dbclient_pool_checkout_failures_total", "dbclient_pool_cleared_total", "dbclient_pool_connections_in_use", "dbclient_pool_connections_open"))
+}
@@ -0,0 +1,10 @@
+package prommetrics
+
+const (
+	defaultNamespace = "dbclient"
+	collectionLabel  = "collection"
+	operationLabel   = "operation"
+	typeLabel        = "type"
+	addressLabel     = "address"
+	reasonLabel      = "reason"
+)
@@ -0,0 +1,10 @@
+package router
+
+import "time"
+
+const (
+	defaultRetryAfter     = 30 * time.Second
+	primaryMissingMessage = "Primary database is not defined"
+	replicaMissingMessage = "Replica database %d is not defined"
+	durationMessage       = "Invalid %s: %v. It must be higher or equal than 0"
+)
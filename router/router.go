@@ -0,0 +1,276 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Config is the structure to define the behaviour of the Router
+// StickyDuration: Time that the reads of a session go to the primary after a write of the same session, so the
+// session reads its own writes despite the replication lag. 0 means until the end of the session
+// RetryAfter: Time that a replica is skipped after failing with a connection error. 0 means 30 seconds
+type Config struct {
+	StickyDuration time.Duration
+	RetryAfter     time.Duration
+}
+
+// Router is the structure implementing the DatabaseInterface that sends the reads (FindOne and FindMany) to a
+// pool of replicas and the rest of operations to the primary. It works with any DatabaseInterface
+// primary: It is the database that receives the writes, and the reads when no replica is available
+// pool: It contains the replicas and their health. It is shared by the copies created by WithContext
+// config: It is the configuration of the Router
+// session: It is the session of the copy, used for the read-your-writes stickiness. The Router created by
+// CreateRouter has no session, so its reads always go to the replicas
+// now: It is the clock of the Router
+type Router struct {
+	primary database.DatabaseInterface
+	pool    *pool
+	config  Config
+	session *session
+	now     func() time.Time
+}
+
+// pool is the list of replicas with their health
+// mutex: It protects next and the health of the replicas
+// next: It is the index of the replica that receives the next read
+type pool struct {
+	mutex    sync.Mutex
+	replicas []*replica
+	next     int
+}
+
+// replica is a read database with the moment until it is considered unhealthy
+type replica struct {
+	database       database.DatabaseInterface
+	unhealthyUntil time.Time
+}
+
+// session contains the moment of the last write of a session
+type session struct {
+	mutex     sync.Mutex
+	written   bool
+	lastWrite time.Time
+}
+
+// sessionKey is the key of the session inside the context
+type sessionKey struct{}
+
+// ContextWithSession returns a copy of the context that carries a new session. All the copies of the Router
+// created by WithContext with this context share the session, so the reads after a write go to the primary
+// ctx: It is the parent context, usually the context of a request
+// It returns the new context
+func ContextWithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, new(session))
+}
+
+// CreateRouter is the constructor for the Router
+// primary: It is the database for the writes
+// replicas: They are the databases for the reads. If there are no replicas, every operation goes to the primary
+// config: It is the configuration of the Router
+// It returns the Router instance and an error
+func CreateRouter(primary database.DatabaseInterface, replicas []database.DatabaseInterface, config Config) (*Router, error) {
+	if primary == nil {
+		return nil, &libraryErrors.InputError{Message: primaryMissingMessage}
+	}
+	if config.StickyDuration < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(durationMessage, "sticky duration", config.StickyDuration)}
+	}
+	if config.RetryAfter < 0 {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(durationMessage, "retry after", config.RetryAfter)}
+	}
+	if config.RetryAfter == 0 {
+		config.RetryAfter = defaultRetryAfter
+	}
+
+	readPool := new(pool)
+	for i, db := range replicas {
+		if db == nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(replicaMissingMessage, i)}
+		}
+		readPool.replicas = append(readPool.replicas, &replica{database: db})
+	}
+	return &Router{primary: primary, pool: readPool, config: config, now: time.Now}, nil
+}
+
+// WithContext returns a copy of the Router that shares the replicas and uses the session of ctx (see
+// ContextWithSession). If ctx does not carry a session, the copy has its own session
+func (router *Router) WithContext(ctx context.Context) *Router {
+	routerCopy := *router
+	if ctxSession, ok := ctx.Value(sessionKey{}).(*session); ok {
+		routerCopy.session = ctxSession
+	} else {
+		routerCopy.session = new(session)
+	}
+	return &routerCopy
+}
+
+// HealthyReplicas returns the number of replicas that receive reads
+func (router *Router) HealthyReplicas() int {
+	now := router.now()
+	router.pool.mutex.Lock()
+	defer router.pool.mutex.Unlock()
+	healthy := 0
+	for _, replica := range router.pool.replicas {
+		if !now.Before(replica.unhealthyUntil) {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// ConnectDb is the function to connect the primary. The replicas must be connected before creating the Router,
+// because each one has its own URI
+func (router *Router) ConnectDb(dbURI, dbName string, timeout int64) error {
+	return router.primary.ConnectDb(dbURI, dbName, timeout)
+}
+
+// DisconnectDb is the function to disconnect the primary and the replicas. It returns the first error
+func (router *Router) DisconnectDb() error {
+	err := router.primary.DisconnectDb()
+	for _, replica := range router.pool.replicas {
+		if replicaErr := replica.database.DisconnectDb(); err == nil {
+			err = replicaErr
+		}
+	}
+	return err
+}
+
+// InsertOne is the function to insert a document in the primary
+func (router *Router) InsertOne(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+	router.session.write(router.now())
+	return router.primary.InsertOne(table, timeout, data)
+}
+
+// InsertMany is the function to insert many documents in the primary
+func (router *Router) InsertMany(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+	router.session.write(router.now())
+	return router.primary.InsertMany(table, timeout, data)
+}
+
+// FindOne is the function to find a document in a replica
+func (router *Router) FindOne(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := router.read(func(db database.DatabaseInterface) error {
+		var err error
+		result, err = db.FindOne(table, timeout, filter)
+		return err
+	})
+	return result, err
+}
+
+// FindMany is the function to find many documents in a replica
+func (router *Router) FindMany(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := router.read(func(db database.DatabaseInterface) error {
+		var err error
+		result, err = db.FindMany(table, timeout, filter)
+		return err
+	})
+	return result, err
+}
+
+// UpdateOne is the function to update a document in the primary
+func (router *Router) UpdateOne(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+	router.session.write(router.now())
+	return router.primary.UpdateOne(table, timeout, filter, newData)
+}
+
+// UpdateMany is the function to update many documents in the primary
+func (router *Router) UpdateMany(table string, timeout int64, filter map[string]interface{}, newData interface{}) ([]map[string]interface{}, error) {
+	router.session.write(router.now())
+	return router.primary.UpdateMany(table, timeout, filter, newData)
+}
+
+// DeleteOne is the function to delete a document in the primary
+func (router *Router) DeleteOne(table string, timeout int64, filter map[string]interface{}) error {
+	router.session.write(router.now())
+	return router.primary.DeleteOne(table, timeout, filter)
+}
+
+// DeleteMany is the function to delete many documents in the primary
+func (router *Router) DeleteMany(table string, timeout int64, filter map[string]interface{}) (int, error) {
+	router.session.write(router.now())
+	return router.primary.DeleteMany(table, timeout, filter)
+}
+
+// read runs the read in the healthy replicas, in turns, until one of them answers. The replicas that fail with
+// a connection error are skipped during RetryAfter. If the session is sticky or no replica answers, the read
+// runs in the primary
+func (router *Router) read(run func(db database.DatabaseInterface) error) error {
+	now := router.now()
+	if router.session.sticky(now, router.config.StickyDuration) {
+		return run(router.primary)
+	}
+	for _, replica := range router.pool.healthy(now) {
+		err := run(replica.database)
+		if !unavailable(err) {
+			return err
+		}
+		router.pool.markUnhealthy(replica, router.now().Add(router.config.RetryAfter))
+	}
+	return run(router.primary)
+}
+
+// healthy returns the healthy replicas, starting by the one whose turn it is
+func (readPool *pool) healthy(now time.Time) []*replica {
+	readPool.mutex.Lock()
+	defer readPool.mutex.Unlock()
+	total := len(readPool.replicas)
+	var replicas []*replica
+	for i := 0; i < total; i++ {
+		replica := readPool.replicas[(readPool.next+i)%total]
+		if !now.Before(replica.unhealthyUntil) {
+			replicas = append(replicas, replica)
+		}
+	}
+	if total > 0 {
+		readPool.next = (readPool.next + 1) % total
+	}
+	return replicas
+}
+
+// markUnhealthy skips the replica until the moment
+func (readPool *pool) markUnhealthy(replica *replica, until time.Time) {
+	readPool.mutex.Lock()
+	defer readPool.mutex.Unlock()
+	replica.unhealthyUntil = until
+}
+
+// write records a write of the session
+func (session *session) write(now time.Time) {
+	if session == nil {
+		return
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.written = true
+	session.lastWrite = now
+}
+
+// sticky reports if the reads of the session must go to the primary
+func (session *session) sticky(now time.Time, duration time.Duration) bool {
+	if session == nil {
+		return false
+	}
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if !session.written {
+		return false
+	}
+	return duration == 0 || now.Sub(session.lastWrite) < duration
+}
+
+// unavailable reports if the error means that the database can not answer, so the read can be retried elsewhere
+func unavailable(err error) bool {
+	switch libraryErrors.Type(err) {
+	case "ConnectionError", "ClientError", "CircuitOpenError", "ShutdownError":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "test"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (clock *clock) get() time.Time {
+	return clock.now
+}
+
+func initializeMock(name string, err error) *database.DatabaseInterfaceMock {
+	return &database.DatabaseInterfaceMock{
+		DisconnectDbFunc: func() error {
+			return err
+		},
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			return map[string]interface{}{"db": name}, err
+		},
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"db": name}, nil
+		},
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			if err != nil {
+				return nil, err
+			}
+			return []map[string]interface{}{{"db": name}}, nil
+		},
+	}
+}
+
+func initializeRouter(t *testing.T, config Config, replicas ...database.DatabaseInterface) (*Router, *clock) {
+	router, err := CreateRouter(initializeMock("primary", nil), replicas, config)
+	assert.NoError(t, err)
+	testClock := &clock{now: time.Now()}
+	router.now = testClock.get
+	return router, testClock
+}
+
+func TestReadRoundRobinSuccess(t *testing.T) {
+	router, _ := initializeRouter(t, Config{}, initializeMock("replica1", nil), initializeMock("replica2", nil))
+
+	var dbs []interface{}
+	for i := 0; i < 4; i++ {
+		result, err := router.FindOne(tableTest, timeoutTest, nil)
+		assert.NoError(t, err)
+		dbs = append(dbs, result["db"])
+	}
+	assert.Equal(t, []interface{}{"replica1", "replica2", "replica1", "replica2"}, dbs)
+
+	_, err := router.InsertOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	result, err := router.FindMany(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "replica1", result[0]["db"])
+}
+
+func TestReadYourWritesSuccess(t *testing.T) {
+	router, testClock := initializeRouter(t, Config{StickyDuration: time.Second}, initializeMock("replica", nil))
+	ctx := ContextWithSession(context.Background())
+	session := router.WithContext(ctx)
+
+	result, err := session.FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", result["db"])
+
+	_, err = session.InsertOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	result, err = router.WithContext(ctx).FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", result["db"])
+	result, err = router.WithContext(context.Background()).FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", result["db"])
+
+	testClock.now = testClock.now.Add(time.Second)
+	result, err = session.FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "replica", result["db"])
+}
+
+func TestReadFallbackSuccess(t *testing.T) {
+	failing := initializeMock("failing", &libraryErrors.ConnectionError{Db: "test"})
+	router, testClock := initializeRouter(t, Config{RetryAfter: time.Minute}, failing, initializeMock("replica", nil))
+
+	for i := 0; i < 2; i++ {
+		result, err := router.FindOne(tableTest, timeoutTest, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "replica", result["db"])
+	}
+	assert.Equal(t, 1, router.HealthyReplicas())
+
+	testClock.now = testClock.now.Add(time.Minute)
+	assert.Equal(t, 2, router.HealthyReplicas())
+}
+
+func TestReadFallbackPrimary(t *testing.T) {
+	router, _ := initializeRouter(t, Config{}, initializeMock("failing", &libraryErrors.ClientError{Message: "test"}))
+
+	result, err := router.FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", result["db"])
+	assert.Equal(t, 0, router.HealthyReplicas())
+}
+
+func TestReadFailedNotExist(t *testing.T) {
+	router, _ := initializeRouter(t, Config{}, initializeMock("replica", &libraryErrors.NotExistError{Message: "test"}))
+
+	_, err := router.FindOne(tableTest, timeoutTest, nil)
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, 1, router.HealthyReplicas())
+}
+
+func TestDisconnectDbFailed(t *testing.T) {
+	router, _ := initializeRouter(t, Config{}, initializeMock("replica", &libraryErrors.ClientError{Message: "test"}))
+
+	err := router.DisconnectDb()
+	var myErr *libraryErrors.ClientError
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestCreateRouterFailed(t *testing.T) {
+	var myErr *libraryErrors.InputError
+
+	_, err := CreateRouter(nil, nil, Config{})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = CreateRouter(initializeMock("primary", nil), []database.DatabaseInterface{nil}, Config{})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = CreateRouter(initializeMock("primary", nil), nil, Config{StickyDuration: -time.Second})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = CreateRouter(initializeMock("primary", nil), nil, Config{RetryAfter: -time.Second})
+	assert.ErrorAs(t, err, &myErr)
+}
@@ -0,0 +1,32 @@
+package schema
+
+const (
+	typeObject  = "object"
+	typeArray   = "array"
+	typeString  = "string"
+	typeNumber  = "number"
+	typeInteger = "integer"
+	typeBoolean = "boolean"
+	typeNull    = "null"
+
+	tagName = "validate"
+
+	invalidSchemaMessage   = "Invalid schema: %v"
+	invalidTypeMessage     = "Invalid type in the schema: %s"
+	invalidTagMessage      = "Invalid validate tag of the field %s: %s"
+	invalidStructMessage   = "Invalid value for the schema: %s. It must be a struct"
+	tableMissingMessage    = "Table of the schema is not defined"
+	violationsMessage      = "The document does not follow the schema of %s: %s"
+	typeViolation          = "must be of type %s"
+	requiredViolation      = "is required"
+	additionalViolation    = "is not allowed"
+	enumViolation          = "must be one of %v"
+	minimumViolation       = "must be higher or equal than %v"
+	maximumViolation       = "must be lower or equal than %v"
+	minLengthViolation     = "must have at least %d characters"
+	maxLengthViolation     = "must have at most %d characters"
+	patternViolation       = "must match the pattern %s"
+	minItemsViolation      = "must have at least %d items"
+	maxItemsViolation      = "must have at most %d items"
+	invalidDocumentMessage = "Invalid document in the operation %s"
+)
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/middleware"
+)
+
+// Registry is the structure with the Schema of each table. The tables without Schema are not validated
+// mutex: It protects schemas
+// schemas: They are the Schema of the tables
+type Registry struct {
+	mutex   sync.RWMutex
+	schemas map[string]*Schema
+}
+
+// CreateRegistry is the constructor for the Registry
+// It returns the Registry instance
+func CreateRegistry() *Registry {
+	return &Registry{schemas: make(map[string]*Schema)}
+}
+
+// Register defines the Schema of the table, replacing the previous one
+// table: It is the name of the table
+// schema: It is the Schema of its documents, created with Parse or FromStruct
+// It returns an InputError if the table or the Schema are not defined
+func (registry *Registry) Register(table string, schema *Schema) error {
+	if table == "" {
+		return &libraryErrors.InputError{Message: tableMissingMessage}
+	}
+	if schema == nil {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(invalidSchemaMessage, "nil")}
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.schemas[table] = schema
+	return nil
+}
+
+// Get returns the Schema of the table and false if it is not registered
+func (registry *Registry) Get(table string) (*Schema, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	schema, found := registry.schemas[table]
+	return schema, found
+}
+
+// Validate checks the document against the Schema of the table
+// table: It is the name of the table
+// document: It is the document to check
+// partial: It skips the required fields, for the updates
+// It returns an InputError with the violations, or nil if the document is valid or the table has no Schema
+func (registry *Registry) Validate(table string, document map[string]interface{}, partial bool) error {
+	schema, found := registry.Get(table)
+	if !found {
+		return nil
+	}
+	var violations []libraryErrors.FieldViolation
+	if partial {
+		violations = schema.ValidatePartial(document)
+	} else {
+		violations = schema.Validate(document)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	details := make([]string, len(violations))
+	for i, violation := range violations {
+		details[i] = violation.Field + " " + violation.Message
+	}
+	return &libraryErrors.InputError{
+		Message:    fmt.Sprintf(violationsMessage, table, strings.Join(details, "; ")),
+		Violations: violations,
+	}
+}
+
+// Wrap creates a Chain around the database that validates the documents of the inserts and the updates
+// db: It is the database to protect
+// registry: It contains the Schema of the tables
+// It returns the Chain
+func Wrap(db database.DatabaseInterface, registry *Registry) *middleware.Chain {
+	return middleware.CreateChain(db, registry.Middleware())
+}
+
+// Middleware returns the Middleware that rejects the inserts and the updates whose documents do not follow the
+// Schema of their table with an InputError, before reaching the database. The updates are validated as partial
+// documents
+func (registry *Registry) Middleware() middleware.Middleware {
+	return middleware.Before(func(operation *middleware.Operation) error {
+		switch operation.Name {
+		case middleware.OperationInsertOne:
+			document, _ := operation.Data.(map[string]interface{})
+			return registry.Validate(operation.Table, document, false)
+		case middleware.OperationInsertMany:
+			documents, _ := operation.Data.([]map[string]interface{})
+			for _, document := range documents {
+				if err := registry.Validate(operation.Table, document, false); err != nil {
+					return err
+				}
+			}
+		case middleware.OperationUpdateOne, middleware.OperationUpdateMany:
+			if _, found := registry.Get(operation.Table); !found {
+				return nil
+			}
+			document, ok := operation.Data.(map[string]interface{})
+			if !ok {
+				return &libraryErrors.InputError{Message: fmt.Sprintf(invalidDocumentMessage, operation.Name)}
+			}
+			return registry.Validate(operation.Table, document, true)
+		}
+		return nil
+	})
+}
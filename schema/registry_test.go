@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "users"
+)
+
+func initializeMock() *database.DatabaseInterfaceMock {
+	return &database.DatabaseInterfaceMock{
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			return data, nil
+		},
+		InsertManyFunc: func(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+			return data, nil
+		},
+		UpdateOneFunc: func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+			return filter, nil
+		},
+	}
+}
+
+func initializeRegistry(t *testing.T) *Registry {
+	registry := CreateRegistry()
+	schema, err := FromStruct(addressTest{})
+	assert.NoError(t, err)
+	assert.NoError(t, registry.Register(tableTest, schema))
+	return registry
+}
+
+func TestWrapSuccess(t *testing.T) {
+	chain := Wrap(initializeMock(), initializeRegistry(t))
+
+	_, err := chain.InsertOne(tableTest, timeoutTest, map[string]interface{}{"city": "test"})
+	assert.NoError(t, err)
+	_, err = chain.InsertMany(tableTest, timeoutTest, []map[string]interface{}{{"city": "test"}})
+	assert.NoError(t, err)
+	_, err = chain.UpdateOne(tableTest, timeoutTest, nil, map[string]interface{}{"other": 1})
+	assert.NoError(t, err)
+	_, err = chain.InsertOne("other", timeoutTest, map[string]interface{}{"city": 1})
+	assert.NoError(t, err)
+}
+
+func TestWrapFailedViolations(t *testing.T) {
+	chain := Wrap(initializeMock(), initializeRegistry(t))
+	var myErr *libraryErrors.InputError
+
+	_, err := chain.InsertOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.ErrorAs(t, err, &myErr)
+	assert.Equal(t, []libraryErrors.FieldViolation{{Field: "city", Message: "is required"}}, myErr.Violations)
+	assert.Equal(t, "The document does not follow the schema of users: city is required", myErr.Error())
+
+	_, err = chain.InsertMany(tableTest, timeoutTest, []map[string]interface{}{{"city": "test"}, {"city": 1}})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = chain.UpdateOne(tableTest, timeoutTest, nil, map[string]interface{}{"city": 1})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = chain.UpdateOne(tableTest, timeoutTest, nil, "invalid")
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestRegisterFailed(t *testing.T) {
+	registry := CreateRegistry()
+	var myErr *libraryErrors.InputError
+
+	assert.ErrorAs(t, registry.Register("", &Schema{}), &myErr)
+	assert.ErrorAs(t, registry.Register(tableTest, nil), &myErr)
+}
@@ -0,0 +1,316 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Schema is a JSON Schema with the keywords supported by the local validation, which are also supported by the
+// $jsonSchema validators of MongoDB. The empty keywords are not checked
+// Type: It is the type of the value (object, array, string, number, integer, boolean or null)
+// Properties: They are the schemas of the fields of an object
+// Required: They are the fields that an object must contain
+// AdditionalProperties: If it is false, an object can not contain fields outside Properties
+// Items: It is the schema of the items of an array
+// Enum: They are the allowed values
+// Minimum and Maximum: They are the limits of a number
+// MinLength and MaxLength: They are the limits of the length of a string
+// Pattern: It is the regular expression that a string must match
+// MinItems and MaxItems: They are the limits of the number of items of an array
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	pattern              *regexp.Regexp
+}
+
+// Parse creates the Schema from a JSON Schema document
+// data: It is the JSON Schema
+// It returns the Schema and an InputError if it is not valid
+func Parse(data []byte) (*Schema, error) {
+	schema := new(Schema)
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(invalidSchemaMessage, err)}
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Map returns the Schema as a JSON Schema document, for example, to create a validator in the DB
+func (schema *Schema) Map() map[string]interface{} {
+	data, _ := json.Marshal(schema)
+	var document map[string]interface{}
+	_ = json.Unmarshal(data, &document)
+	return document
+}
+
+// Validate checks the document against the Schema
+// document: It is the document to check
+// It returns the violations of the document, sorted by field
+func (schema *Schema) Validate(document map[string]interface{}) []libraryErrors.FieldViolation {
+	var violations []libraryErrors.FieldViolation
+	schema.validate("", document, false, &violations)
+	sortViolations(violations)
+	return violations
+}
+
+// ValidatePartial checks the fields of the document against the Schema without checking the required fields of the
+// first level, for the updates that only contain the fields that change. The embedded documents of the update
+// replace the previous ones, so their required fields are checked
+// document: It is the document to check
+// It returns the violations of the document, sorted by field
+func (schema *Schema) ValidatePartial(document map[string]interface{}) []libraryErrors.FieldViolation {
+	var violations []libraryErrors.FieldViolation
+	schema.validate("", document, true, &violations)
+	sortViolations(violations)
+	return violations
+}
+
+// compile checks the types and compiles the patterns of the Schema and its children
+func (schema *Schema) compile() error {
+	switch schema.Type {
+	case "", typeObject, typeArray, typeString, typeNumber, typeInteger, typeBoolean, typeNull:
+	default:
+		return &libraryErrors.InputError{Message: fmt.Sprintf(invalidTypeMessage, schema.Type)}
+	}
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(invalidSchemaMessage, err)}
+		}
+		schema.pattern = pattern
+	}
+	for _, property := range schema.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+	if schema.Items != nil {
+		return schema.Items.compile()
+	}
+	return nil
+}
+
+// validate checks the value against the Schema and adds the violations with the path of the field
+func (schema *Schema) validate(field string, value interface{}, partial bool, violations *[]libraryErrors.FieldViolation) {
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, libraryErrors.FieldViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		add(typeViolation, schema.Type)
+		return
+	}
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed interface{}) bool { return equal(allowed, value) }) {
+		add(enumViolation, schema.Enum)
+	}
+
+	if document, ok := asObject(value); ok {
+		schema.validateObject(field, document, partial, violations)
+		return
+	}
+	switch typed := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(typed)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			add(minLengthViolation, *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			add(maxLengthViolation, *schema.MaxLength)
+		}
+		if schema.pattern != nil && !schema.pattern.MatchString(typed) {
+			add(patternViolation, schema.Pattern)
+		}
+	default:
+		if number, ok := toFloat(value); ok {
+			if schema.Minimum != nil && number < *schema.Minimum {
+				add(minimumViolation, *schema.Minimum)
+			}
+			if schema.Maximum != nil && number > *schema.Maximum {
+				add(maximumViolation, *schema.Maximum)
+			}
+			return
+		}
+		items := reflect.ValueOf(value)
+		if value == nil || (items.Kind() != reflect.Slice && items.Kind() != reflect.Array) {
+			return
+		}
+		if schema.MinItems != nil && items.Len() < *schema.MinItems {
+			add(minItemsViolation, *schema.MinItems)
+		}
+		if schema.MaxItems != nil && items.Len() > *schema.MaxItems {
+			add(maxItemsViolation, *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i := 0; i < items.Len(); i++ {
+				schema.Items.validate(fmt.Sprintf("%s[%d]", field, i), items.Index(i).Interface(), false, violations)
+			}
+		}
+	}
+}
+
+// validateObject checks the fields of an object
+func (schema *Schema) validateObject(field string, document map[string]interface{}, partial bool, violations *[]libraryErrors.FieldViolation) {
+	path := func(key string) string {
+		if field == "" {
+			return key
+		}
+		return field + "." + key
+	}
+	if !partial {
+		for _, key := range schema.Required {
+			if _, found := document[key]; !found {
+				*violations = append(*violations, libraryErrors.FieldViolation{Field: path(key), Message: requiredViolation})
+			}
+		}
+	}
+	for key, value := range document {
+		schema.validateField(field, key, value, partial, violations)
+	}
+}
+
+// validateField checks a field of an object. In the partial documents, the keys in dot notation (like the keys of
+// $set) are checked against the properties of the embedded objects and the items of the arrays they point to. The
+// values are checked as whole values, with their required fields, because they replace the previous ones
+func (schema *Schema) validateField(field string, key string, value interface{}, partial bool, violations *[]libraryErrors.FieldViolation) {
+	path := key
+	if field != "" {
+		path = field + "." + key
+	}
+	head, rest, dotted := strings.Cut(key, ".")
+	if _, found := schema.Properties[key]; found || !partial || !dotted {
+		head, rest, dotted = key, "", false
+	}
+	property, found := schema.Properties[head]
+	if !found {
+		if schema.AdditionalProperties != nil && !*schema.AdditionalProperties && key != "_id" {
+			*violations = append(*violations, libraryErrors.FieldViolation{Field: path, Message: additionalViolation})
+		}
+		return
+	}
+	if field != "" {
+		head = field + "." + head
+	}
+	if !dotted {
+		property.validate(head, value, false, violations)
+		return
+	}
+	position, remainder, nested := strings.Cut(rest, ".")
+	if property.Items == nil || !isPosition(position) {
+		property.validateField(head, rest, value, partial, violations)
+		return
+	}
+	head = head + "." + position
+	if !nested {
+		property.Items.validate(head, value, false, violations)
+		return
+	}
+	property.Items.validateField(head, remainder, value, partial, violations)
+}
+
+// isPosition reports if the key in dot notation is the position of an array item, like 0 or the operators $ and $[]
+func isPosition(key string) bool {
+	if strings.HasPrefix(key, "$") {
+		return true
+	}
+	_, err := strconv.Atoi(key)
+	return err == nil
+}
+
+// hasType reports if the value is of the JSON type
+func hasType(value interface{}, jsonType string) bool {
+	switch jsonType {
+	case typeNull:
+		return value == nil
+	case typeString:
+		_, ok := value.(string)
+		return ok
+	case typeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case typeNumber:
+		_, ok := toFloat(value)
+		return ok
+	case typeInteger:
+		number, ok := toFloat(value)
+		return ok && number == math.Trunc(number)
+	case typeObject:
+		_, ok := asObject(value)
+		return ok
+	case typeArray:
+		kind := reflect.ValueOf(value).Kind()
+		return value != nil && (kind == reflect.Slice || kind == reflect.Array)
+	default:
+		return true
+	}
+}
+
+// asObject converts the maps with string keys (like bson.M) to map[string]interface{}
+func asObject(value interface{}) (map[string]interface{}, bool) {
+	if document, ok := value.(map[string]interface{}); ok {
+		return document, true
+	}
+	object := reflect.ValueOf(value)
+	if object.Kind() != reflect.Map || object.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	document := make(map[string]interface{}, object.Len())
+	iterator := object.MapRange()
+	for iterator.Next() {
+		document[iterator.Key().String()] = iterator.Value().Interface()
+	}
+	return document, true
+}
+
+// toFloat converts the numbers of any Go type to float64
+func toFloat(value interface{}) (float64, bool) {
+	number := reflect.ValueOf(value)
+	switch number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(number.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(number.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return number.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// equal compares the values of an enum, considering equal the numbers of different Go types
+func equal(allowed, value interface{}) bool {
+	allowedNumber, allowedIsNumber := toFloat(allowed)
+	number, isNumber := toFloat(value)
+	if allowedIsNumber && isNumber {
+		return allowedNumber == number
+	}
+	return reflect.DeepEqual(allowed, value)
+}
+
+// sortViolations sorts the violations by field, so the messages are stable
+func sortViolations(violations []libraryErrors.FieldViolation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Field < violations[j].Field
+	})
+}
@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"testing"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const schemaTest = `{
+	"type": "object",
+	"required": ["name", "age"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 2, "pattern": "^[a-z]+$"},
+		"age": {"type": "integer", "minimum": 0, "maximum": 150},
+		"role": {"enum": ["admin", "user"]},
+		"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+		"address": {"type": "object", "required": ["city"], "properties": {"city": {"type": "string"}}}
+	}
+}`
+
+func TestValidateSuccess(t *testing.T) {
+	schema, err := Parse([]byte(schemaTest))
+	assert.NoError(t, err)
+
+	violations := schema.Validate(map[string]interface{}{
+		"_id":     1,
+		"name":    "test",
+		"age":     int32(30),
+		"role":    "admin",
+		"tags":    []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "test"},
+	})
+	assert.Empty(t, violations)
+	assert.Empty(t, schema.ValidatePartial(map[string]interface{}{"age": 31.0}))
+}
+
+func TestValidateViolations(t *testing.T) {
+	schema, err := Parse([]byte(schemaTest))
+	assert.NoError(t, err)
+
+	violations := schema.Validate(map[string]interface{}{
+		"name":    "T",
+		"role":    "guest",
+		"tags":    []string{"a", "b", "c"},
+		"address": map[string]interface{}{"city": 1},
+		"extra":   true,
+	})
+	assert.Equal(t, []libraryErrors.FieldViolation{
+		{Field: "address.city", Message: "must be of type string"},
+		{Field: "age", Message: "is required"},
+		{Field: "extra", Message: "is not allowed"},
+		{Field: "name", Message: "must have at least 2 characters"},
+		{Field: "name", Message: "must match the pattern ^[a-z]+$"},
+		{Field: "role", Message: "must be one of [admin user]"},
+		{Field: "tags", Message: "must have at most 2 items"},
+	}, violations)
+
+	violations = schema.ValidatePartial(map[string]interface{}{"age": 1.5, "tags": []interface{}{1}})
+	assert.Equal(t, []libraryErrors.FieldViolation{
+		{Field: "age", Message: "must be of type integer"},
+		{Field: "tags[0]", Message: "must be of type string"},
+	}, violations)
+}
+
+func TestValidatePartialDotNotation(t *testing.T) {
+	schema, err := Parse([]byte(schemaTest))
+	assert.NoError(t, err)
+
+	assert.Empty(t, schema.ValidatePartial(map[string]interface{}{"address.city": "test", "tags.0": "a", "tags.$": "b"}))
+	violations := schema.ValidatePartial(map[string]interface{}{"address.city": 5, "address.zip": 1, "tags.1": 2, "extra.field": 1})
+	assert.Equal(t, []libraryErrors.FieldViolation{
+		{Field: "address.city", Message: "must be of type string"},
+		{Field: "extra.field", Message: "is not allowed"},
+		{Field: "tags.1", Message: "must be of type string"},
+	}, violations)
+	violations = schema.ValidatePartial(map[string]interface{}{"address": map[string]interface{}{}})
+	assert.Equal(t, []libraryErrors.FieldViolation{{Field: "address.city", Message: "is required"}}, violations)
+	violations = schema.Validate(map[string]interface{}{"name": "test", "age": 1, "address.city": "test"})
+	assert.Equal(t, []libraryErrors.FieldViolation{{Field: "address.city", Message: "is not allowed"}}, violations)
+}
+
+func TestParseFailed(t *testing.T) {
+	var myErr *libraryErrors.InputError
+
+	_, err := Parse([]byte("{"))
+	assert.ErrorAs(t, err, &myErr)
+	_, err = Parse([]byte(`{"type": "date"}`))
+	assert.ErrorAs(t, err, &myErr)
+	_, err = Parse([]byte(`{"properties": {"name": {"pattern": "("}}}`))
+	assert.ErrorAs(t, err, &myErr)
+}
+
+func TestSchemaMap(t *testing.T) {
+	schema, err := Parse([]byte(`{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":       "object",
+		"required":   []interface{}{"name"},
+		"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+	}, schema.Map())
+}
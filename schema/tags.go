@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FromStruct creates the Schema of the documents with the fields of a struct. The name of each field is taken
+// from its bson or json tag, and the rules from its validate tag, separated by commas:
+// required, min=N and max=N (value of the numbers, length of the strings or items of the slices),
+// enum=a|b|c and pattern=REGEXP, which must be the last rule. The nested structs are nested objects
+// value: It is a struct or a pointer to a struct
+// It returns the Schema and an InputError if some tag is not valid
+func FromStruct(value interface{}) (*Schema, error) {
+	structType := reflect.TypeOf(value)
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, &libraryErrors.InputError{Message: fmt.Sprintf(invalidStructMessage, structType)}
+	}
+	schema, err := fromType(structType)
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// fromType creates the Schema of a Go type
+func fromType(goType reflect.Type) (*Schema, error) {
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	switch goType.Kind() {
+	case reflect.String:
+		return &Schema{Type: typeString}, nil
+	case reflect.Bool:
+		return &Schema{Type: typeBoolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: typeInteger}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: typeNumber}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := fromType(goType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: typeArray, Items: items}, nil
+	case reflect.Map:
+		return &Schema{Type: typeObject}, nil
+	case reflect.Struct:
+		if goType == timeType {
+			return &Schema{}, nil
+		}
+		return fromStruct(goType)
+	default:
+		return &Schema{}, nil
+	}
+}
+
+// fromStruct creates the Schema of the fields of a struct
+func fromStruct(structType reflect.Type) (*Schema, error) {
+	schema := &Schema{Type: typeObject, Properties: make(map[string]*Schema)}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := fieldName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		property, err := fromType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		required, err := applyTag(property, field.Tag.Get(tagName))
+		if err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(invalidTagMessage, field.Name, err)}
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return schema, nil
+}
+
+// fieldName returns the name of the field in the documents
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"bson", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// applyTag adds the rules of the validate tag to the Schema of the field
+func applyTag(schema *Schema, tag string) (bool, error) {
+	required := false
+	for tag != "" {
+		var rule string
+		if strings.HasPrefix(tag, "pattern=") {
+			rule, tag = tag, ""
+		} else {
+			rule, tag, _ = strings.Cut(tag, ",")
+		}
+		name, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "required":
+			required = true
+		case "min", "max":
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("%s", rule)
+			}
+			setLimit(schema, name == "min", limit)
+		case "enum":
+			for _, option := range strings.Split(value, "|") {
+				schema.Enum = append(schema.Enum, enumValue(schema.Type, option))
+			}
+		case "pattern":
+			schema.Pattern = value
+		default:
+			return false, fmt.Errorf("%s", rule)
+		}
+	}
+	return required, nil
+}
+
+// setLimit applies min or max to the keyword that corresponds to the type of the field
+func setLimit(schema *Schema, minimum bool, limit float64) {
+	length := int(limit)
+	switch schema.Type {
+	case typeString:
+		if minimum {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case typeArray:
+		if minimum {
+			schema.MinItems = &length
+		} else {
+			schema.MaxItems = &length
+		}
+	default:
+		if minimum {
+			schema.Minimum = &limit
+		} else {
+			schema.Maximum = &limit
+		}
+	}
+}
+
+// enumValue converts an option of enum to the type of the field
+func enumValue(jsonType, option string) interface{} {
+	switch jsonType {
+	case typeInteger, typeNumber:
+		if number, err := strconv.ParseFloat(option, 64); err == nil {
+			return number
+		}
+	case typeBoolean:
+		if boolean, err := strconv.ParseBool(option); err == nil {
+			return boolean
+		}
+	}
+	return option
+}
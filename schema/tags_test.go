@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type addressTest struct {
+	City string `json:"city" validate:"required"`
+}
+
+type userTest struct {
+	Name      string      `bson:"name" validate:"required,min=2,max=10"`
+	Age       int         `json:"age" validate:"min=0,max=150"`
+	Role      string      `json:"role" validate:"enum=admin|user"`
+	Code      string      `json:"code" validate:"pattern=^[A-Z]{2},[0-9]+$"`
+	Tags      []string    `json:"tags" validate:"max=2"`
+	Address   addressTest `json:"address"`
+	CreatedAt time.Time   `json:"created_at"`
+	Ignored   string      `json:"-"`
+}
+
+func TestFromStructSuccess(t *testing.T) {
+	schema, err := FromStruct(&userTest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name"}, schema.Required)
+	assert.Len(t, schema.Properties, 7)
+	assert.Equal(t, typeInteger, schema.Properties["age"].Type)
+	assert.Equal(t, []string{"city"}, schema.Properties["address"].Required)
+
+	assert.Empty(t, schema.Validate(map[string]interface{}{
+		"name":       "test",
+		"age":        20,
+		"role":       "user",
+		"code":       "AB,12",
+		"tags":       []string{"a"},
+		"address":    map[string]interface{}{"city": "test"},
+		"created_at": time.Now(),
+	}))
+	assert.Equal(t, []libraryErrors.FieldViolation{
+		{Field: "address.city", Message: "is required"},
+		{Field: "age", Message: "must be lower or equal than 150"},
+		{Field: "code", Message: "must match the pattern ^[A-Z]{2},[0-9]+$"},
+		{Field: "name", Message: "is required"},
+	}, schema.Validate(map[string]interface{}{"age": 200, "code": "12", "address": map[string]interface{}{}}))
+}
+
+func TestFromStructFailed(t *testing.T) {
+	var myErr *libraryErrors.InputError
+
+	_, err := FromStruct("test")
+	assert.ErrorAs(t, err, &myErr)
+	_, err = FromStruct(struct {
+		Name string `validate:"unknown"`
+	}{})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = FromStruct(struct {
+		Age int `validate:"min=a"`
+	}{})
+	assert.ErrorAs(t, err, &myErr)
+}
@@ -0,0 +1,15 @@
+package telemetry
+
+const (
+	instrumentationName = "github.com/cristianat98/dbclientgo/telemetry"
+	durationMetric      = "db.client.operation.duration"
+	errorsMetric        = "db.client.operation.errors"
+)
+
+// Attributes of the database semantic conventions
+const (
+	systemKey     = "db.system"
+	collectionKey = "db.collection.name"
+	operationKey  = "db.operation"
+	errorTypeKey  = "error.type"
+)
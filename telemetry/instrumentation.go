@@ -0,0 +1,142 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option is a function to configure the Instrumentation
+type Option func(instrumentation *config)
+
+// config contains the providers used by the Instrumentation
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider defines the TracerProvider. If it is not defined, the global one is used
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *config) {
+		config.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider defines the MeterProvider. If it is not defined, the global one is used
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(config *config) {
+		config.meterProvider = provider
+	}
+}
+
+// Instrumentation is the structure that creates the spans and records the metrics of the operations
+// system: It is the value of the db.system attribute (mongodb, postgresql...)
+// tracer: It is the tracer to create the spans
+// duration: It is the histogram with the duration of the operations in seconds
+// errors: It is the counter of the operations that failed
+type Instrumentation struct {
+	system   string
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// CreateInstrumentation is the constructor for the Instrumentation
+// system: It is the value of the db.system attribute (mongodb, postgresql...)
+// opts: They are the options to configure the providers
+// It returns the Instrumentation instance and an error
+func CreateInstrumentation(system string, opts ...Option) (*Instrumentation, error) {
+	config := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	meter := config.meterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram(
+		durationMetric,
+		metric.WithDescription("Duration of the database client operations"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errorsCounter, err := meter.Int64Counter(
+		errorsMetric,
+		metric.WithDescription("Number of database client operations that failed"),
+		metric.WithUnit("{operation}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		system:   system,
+		tracer:   config.tracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		errors:   errorsCounter,
+	}, nil
+}
+
+// Wrap creates a Chain around the database that instruments every operation. Use Chain.WithContext to
+// create the spans as children of the span of the caller
+// db: It is the database to instrument
+// system: It is the value of the db.system attribute (mongodb, postgresql...)
+// opts: They are the options to configure the providers
+// It returns the Chain and an error
+func Wrap(db database.DatabaseInterface, system string, opts ...Option) (*middleware.Chain, error) {
+	instrumentation, err := CreateInstrumentation(system, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return middleware.CreateChain(db, instrumentation.Middleware()), nil
+}
+
+// Middleware returns the Middleware that creates a span per operation and records its duration and errors
+func (instrumentation *Instrumentation) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(operation *middleware.Operation) {
+			ctx := operation.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			attributes := []attribute.KeyValue{
+				attribute.String(systemKey, instrumentation.system),
+				attribute.String(operationKey, operation.Name),
+			}
+			spanName := operation.Name
+			if operation.Table != "" && operation.Name != middleware.OperationConnectDb {
+				attributes = append(attributes, attribute.String(collectionKey, operation.Table))
+				spanName += " " + operation.Table
+			}
+
+			ctx, span := instrumentation.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+			defer span.End()
+			operation.Context = ctx
+
+			start := time.Now()
+			next(operation)
+			elapsed := time.Since(start)
+
+			if operation.Err != nil {
+				errorType := attribute.String(errorTypeKey, libraryErrors.Type(operation.Err))
+				attributes = append(attributes, errorType)
+				span.SetAttributes(errorType)
+				span.RecordError(operation.Err)
+				span.SetStatus(codes.Error, operation.Err.Error())
+				instrumentation.errors.Add(ctx, 1, metric.WithAttributes(attributes...))
+			}
+			instrumentation.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attributes...))
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "test"
+	systemTest  = "mongodb"
+)
+
+func initializeInstrumentation(t *testing.T) (*tracetest.SpanRecorder, *sdkmetric.ManualReader, *sdktrace.TracerProvider, *middleware.Chain) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	mock := &database.DatabaseInterfaceMock{
+		FindOneFunc: func(table string, timeout int64, filter map[string]interface{}) (map[string]interface{}, error) {
+			return map[string]interface{}{"test": "test"}, nil
+		},
+		DeleteOneFunc: func(table string, timeout int64, filter map[string]interface{}) error {
+			return &libraryErrors.NotExistError{Message: "test"}
+		},
+	}
+	chain, err := Wrap(mock, systemTest, WithTracerProvider(tracerProvider), WithMeterProvider(meterProvider))
+	assert.NoError(t, err)
+	return recorder, reader, tracerProvider, chain
+}
+
+func TestInstrumentationSpanSuccess(t *testing.T) {
+	recorder, _, _, db := initializeInstrumentation(t)
+
+	result, err := db.FindOne(tableTest, timeoutTest, map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"test": "test"}, result)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "FindOne test", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String(systemKey, systemTest),
+		attribute.String(operationKey, "FindOne"),
+		attribute.String(collectionKey, tableTest),
+	}, spans[0].Attributes())
+}
+
+func TestInstrumentationSpanError(t *testing.T) {
+	recorder, _, _, db := initializeInstrumentation(t)
+
+	err := db.DeleteOne(tableTest, timeoutTest, map[string]interface{}{})
+	var myErr *libraryErrors.NotExistError
+	assert.ErrorAs(t, err, &myErr)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String(errorTypeKey, "NotExistError"))
+	assert.Len(t, spans[0].Events(), 1)
+}
+
+func TestInstrumentationSpanParent(t *testing.T) {
+	recorder, _, tracerProvider, db := initializeInstrumentation(t)
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+	_, err := db.WithContext(ctx).FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestInstrumentationMetrics(t *testing.T) {
+	_, reader, _, db := initializeInstrumentation(t)
+
+	_, err := db.FindOne(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	_ = db.DeleteOne(tableTest, timeoutTest, nil)
+	_ = db.DeleteOne(tableTest, timeoutTest, nil)
+
+	var data metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &data))
+	assert.Len(t, data.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, item := range data.ScopeMetrics[0].Metrics {
+		metrics[item.Name] = item
+	}
+
+	histogram, ok := metrics[durationMetric].Data.(metricdata.Histogram[float64])
+	assert.True(t, ok)
+	counts := map[string]uint64{}
+	for _, point := range histogram.DataPoints {
+		operation, _ := point.Attributes.Value(operationKey)
+		counts[operation.AsString()] += point.Count
+	}
+	assert.Equal(t, map[string]uint64{"FindOne": 1, "DeleteOne": 2}, counts)
+
+	counter, ok := metrics[errorsMetric].Data.(metricdata.Sum[int64])
+	assert.True(t, ok)
+	assert.Len(t, counter.DataPoints, 1)
+	assert.Equal(t, int64(2), counter.DataPoints[0].Value)
+	errorType, _ := counter.DataPoints[0].Attributes.Value(errorTypeKey)
+	assert.Equal(t, "NotExistError", errorType.AsString())
+}
@@ -0,0 +1,8 @@
+package tenancy
+
+const (
+	defaultKey             = "tenant_id"
+	tenantMissingMessage   = "The tenant is not defined in the context of the operation %s"
+	tenantOverrideMessage  = "The field %s can not be changed: the operation belongs to the tenant %s"
+	invalidDocumentMessage = "Invalid document in the operation %s"
+)
@@ -0,0 +1,159 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/cristianat98/dbclientgo/middleware"
+)
+
+// Config is the structure to configure the isolation of the tenants in shared tables
+// Key: It is the field of the documents with the tenant. If it is empty, tenant_id is used
+// Strip: It removes the field of the tenant from the documents returned
+type Config struct {
+	Key   string
+	Strip bool
+}
+
+// Wrap creates a Chain around the database that scopes every operation to the tenant of its context. Use
+// Chain.WithContext with a context created by database.ContextWithTenant, or Scope, to define the tenant
+// db: It is the database with the shared tables
+// config: It is the configuration of the isolation
+// It returns the Chain
+func Wrap(db database.DatabaseInterface, config Config) *middleware.Chain {
+	return middleware.CreateChain(db, Middleware(config))
+}
+
+// Scope returns a copy of the Chain whose operations belong to the tenant
+// chain: It is the Chain created by Wrap or with the Middleware
+// tenant: It is the identifier of the tenant
+// It returns the Chain of the tenant
+func Scope(chain *middleware.Chain, tenant string) *middleware.Chain {
+	return chain.WithContext(database.ContextWithTenant(context.Background(), tenant))
+}
+
+// Middleware returns the Middleware that injects the tenant of the context into every filter and document. The
+// operations without tenant, or whose filter or data try to use another tenant, are rejected with an InputError
+// before reaching the database. ConnectDb and DisconnectDb are not scoped
+// config: It is the configuration of the isolation
+func Middleware(config Config) middleware.Middleware {
+	key := config.Key
+	if key == "" {
+		key = defaultKey
+	}
+	return func(next middleware.Handler) middleware.Handler {
+		return func(operation *middleware.Operation) {
+			if operation.Name == middleware.OperationConnectDb || operation.Name == middleware.OperationDisconnectDb {
+				next(operation)
+				return
+			}
+			tenant, ok := database.TenantFromContext(operation.Context)
+			if !ok {
+				operation.Err = &libraryErrors.InputError{Message: fmt.Sprintf(tenantMissingMessage, operation.Name)}
+				return
+			}
+			if err := scope(operation, key, tenant); err != nil {
+				operation.Err = err
+				return
+			}
+
+			next(operation)
+			if config.Strip && operation.Err == nil {
+				operation.Result = strip(operation.Result, key)
+			}
+		}
+	}
+}
+
+// scope replaces the filter and the data of the operation with copies that contain the tenant
+func scope(operation *middleware.Operation, key, tenant string) error {
+	if operation.Name != middleware.OperationInsertOne && operation.Name != middleware.OperationInsertMany {
+		filter, err := inject(operation.Filter, key, tenant)
+		if err != nil {
+			return err
+		}
+		operation.Filter = filter
+	}
+
+	switch data := operation.Data.(type) {
+	case map[string]interface{}:
+		if operation.Name == middleware.OperationInsertOne {
+			document, err := inject(data, key, tenant)
+			if err != nil {
+				return err
+			}
+			operation.Data = document
+		} else if err := check(data, key, tenant); err != nil {
+			return err
+		}
+	case []map[string]interface{}:
+		documents := make([]map[string]interface{}, len(data))
+		for i, document := range data {
+			scoped, err := inject(document, key, tenant)
+			if err != nil {
+				return err
+			}
+			documents[i] = scoped
+		}
+		operation.Data = documents
+	case nil:
+	default:
+		if operation.Name == middleware.OperationInsertOne || operation.Name == middleware.OperationInsertMany {
+			return &libraryErrors.InputError{Message: fmt.Sprintf(invalidDocumentMessage, operation.Name)}
+		}
+	}
+	return nil
+}
+
+// inject returns a copy of the document with the tenant
+func inject(document map[string]interface{}, key, tenant string) (map[string]interface{}, error) {
+	if err := check(document, key, tenant); err != nil {
+		return nil, err
+	}
+	scoped := make(map[string]interface{}, len(document)+1)
+	for field, value := range document {
+		scoped[field] = value
+	}
+	scoped[key] = tenant
+	return scoped, nil
+}
+
+// check returns an InputError if the document has another tenant
+func check(document map[string]interface{}, key, tenant string) error {
+	if value, found := document[key]; found && value != tenant {
+		return &libraryErrors.InputError{Message: fmt.Sprintf(tenantOverrideMessage, key, tenant)}
+	}
+	return nil
+}
+
+// strip returns a copy of the result without the field of the tenant
+func strip(result interface{}, key string) interface{} {
+	switch documents := result.(type) {
+	case map[string]interface{}:
+		return withoutKey(documents, key)
+	case []map[string]interface{}:
+		stripped := make([]map[string]interface{}, len(documents))
+		for i, document := range documents {
+			stripped[i] = withoutKey(document, key)
+		}
+		return stripped
+	default:
+		return result
+	}
+}
+
+// withoutKey returns a copy of the document without the key
+func withoutKey(document map[string]interface{}, key string) map[string]interface{} {
+	if document == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(document))
+	for field, value := range document {
+		if field != key {
+			copied[field] = value
+		}
+	}
+	return copied
+}
@@ -0,0 +1,106 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	timeoutTest = 5
+	tableTest   = "test"
+	tenantTest  = "tenant1"
+)
+
+func initializeMock() *database.DatabaseInterfaceMock {
+	return &database.DatabaseInterfaceMock{
+		ConnectDbFunc: func(dbURI, dbName string, timeout int64) error {
+			return nil
+		},
+		InsertOneFunc: func(table string, timeout int64, data map[string]interface{}) (map[string]interface{}, error) {
+			return data, nil
+		},
+		InsertManyFunc: func(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+			return data, nil
+		},
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			return []map[string]interface{}{filter}, nil
+		},
+		UpdateOneFunc: func(table string, timeout int64, filter map[string]interface{}, newData interface{}) (map[string]interface{}, error) {
+			return filter, nil
+		},
+		DeleteManyFunc: func(table string, timeout int64, filter map[string]interface{}) (int, error) {
+			return len(filter), nil
+		},
+	}
+}
+
+func TestInsertSuccess(t *testing.T) {
+	chain := Scope(Wrap(initializeMock(), Config{}), tenantTest)
+
+	document := map[string]interface{}{"name": "test"}
+	result, err := chain.InsertOne(tableTest, timeoutTest, document)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "test", "tenant_id": tenantTest}, result)
+	assert.Equal(t, map[string]interface{}{"name": "test"}, document)
+
+	results, err := chain.InsertMany(tableTest, timeoutTest, []map[string]interface{}{{"name": "test"}, {"tenant_id": tenantTest}})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"name": "test", "tenant_id": tenantTest}, {"tenant_id": tenantTest}}, results)
+}
+
+func TestFilterSuccess(t *testing.T) {
+	chain := Wrap(initializeMock(), Config{Key: "tenant"})
+	chain = chain.WithContext(database.ContextWithTenant(context.Background(), tenantTest))
+
+	results, err := chain.FindMany(tableTest, timeoutTest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"tenant": tenantTest}}, results)
+
+	result, err := chain.UpdateOne(tableTest, timeoutTest, map[string]interface{}{"name": "test"}, map[string]interface{}{"tenant": tenantTest})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "test", "tenant": tenantTest}, result)
+
+	deleted, err := chain.DeleteMany(tableTest, timeoutTest, map[string]interface{}{"name": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestStripSuccess(t *testing.T) {
+	chain := Scope(Wrap(initializeMock(), Config{Strip: true}), tenantTest)
+
+	result, err := chain.InsertOne(tableTest, timeoutTest, map[string]interface{}{"name": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "test"}, result)
+
+	results, err := chain.FindMany(tableTest, timeoutTest, map[string]interface{}{"name": "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"name": "test"}}, results)
+}
+
+func TestTenancyFailedTenantMissing(t *testing.T) {
+	chain := Wrap(initializeMock(), Config{})
+
+	_, err := chain.FindMany(tableTest, timeoutTest, nil)
+	var myErr *libraryErrors.InputError
+	assert.ErrorAs(t, err, &myErr)
+	assert.NoError(t, chain.ConnectDb("test://test", "test", timeoutTest))
+}
+
+func TestTenancyFailedOverride(t *testing.T) {
+	chain := Scope(Wrap(initializeMock(), Config{}), tenantTest)
+	other := map[string]interface{}{"tenant_id": "tenant2"}
+	var myErr *libraryErrors.InputError
+
+	_, err := chain.FindMany(tableTest, timeoutTest, other)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = chain.InsertOne(tableTest, timeoutTest, other)
+	assert.ErrorAs(t, err, &myErr)
+	_, err = chain.InsertMany(tableTest, timeoutTest, []map[string]interface{}{{"name": "test"}, other})
+	assert.ErrorAs(t, err, &myErr)
+	_, err = chain.UpdateOne(tableTest, timeoutTest, nil, other)
+	assert.ErrorAs(t, err, &myErr)
+}
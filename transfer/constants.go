@@ -0,0 +1,15 @@
+package transfer
+
+const (
+	defaultBatchSize = 500
+	defaultTimeout   = 10
+
+	formatMessage    = "Invalid format: %s. It must be ndjson, json or csv"
+	batchSizeMessage = "Invalid batch size: %d. It must be higher or equal than 0"
+	timeoutMessage   = "Invalid timeout: %d. It must be higher or equal than 0"
+	columnMessage    = "Invalid column %d: the field is not defined"
+	typeMessage      = "Invalid type %s of the column %s"
+	documentMessage  = "Invalid document %d: %v"
+	valueMessage     = "Invalid value %q of the column %s in the row %d: %v"
+	headerMessage    = "The CSV has no header"
+)
@@ -0,0 +1,186 @@
+package transfer
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"io"
+
+	"github.com/cristianat98/dbclientgo/database"
+	"github.com/cristianat98/dbclientgo/internal/bsonutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Export writes the documents of a table that match the filter of the Options
+// ctx: It is the context of the export. It is checked between batches
+// db: It is the database with the table
+// table: It is the table to export
+// output: It is the destination of the documents
+// options: They are the format, the columns and the rest of Options
+// It returns the number of documents written and an error
+func Export(ctx context.Context, db database.DatabaseInterface, table string, output io.Writer, options Options) (int, error) {
+	options, err := options.withDefaults()
+	if err != nil {
+		return 0, err
+	}
+	documents, err := db.FindMany(table, options.Timeout, options.Filter)
+	if err != nil {
+		return 0, err
+	}
+
+	buffer := bufio.NewWriter(output)
+	var write func(document map[string]interface{}) error
+	var finish func() error
+	switch options.Format {
+	case FormatCSV:
+		columns := options.Columns
+		if len(columns) == 0 {
+			columns = documentColumns(documents)
+		}
+		if options.Mapping != nil {
+			options.Mapping(columnTypes(documents, columns))
+		}
+		write, finish = csvWriter(buffer, columns)
+	default:
+		write, finish = jsonWriter(buffer, options.Format == FormatJSON, options.Canonical)
+	}
+
+	for i, document := range documents {
+		if i > 0 && i%options.BatchSize == 0 {
+			if err := ctx.Err(); err != nil {
+				return i, err
+			}
+			options.report(i)
+		}
+		if err := write(document); err != nil {
+			return i, err
+		}
+	}
+	if err := finish(); err != nil {
+		return len(documents), err
+	}
+	if err := buffer.Flush(); err != nil {
+		return len(documents), err
+	}
+	options.report(len(documents))
+	return len(documents), nil
+}
+
+// jsonWriter returns the functions to write the documents as NDJSON or as a JSON array
+func jsonWriter(output *bufio.Writer, array, canonical bool) (func(map[string]interface{}) error, func() error) {
+	written := 0
+	write := func(document map[string]interface{}) error {
+		content, err := bson.MarshalExtJSON(bsonutil.OrderDocument(document), canonical, false)
+		if err != nil {
+			return err
+		}
+		separator := "\n"
+		if array {
+			separator = ",\n"
+			if written == 0 {
+				separator = "[\n"
+			}
+		} else if written == 0 {
+			separator = ""
+		}
+		written++
+		if _, err := output.WriteString(separator); err != nil {
+			return err
+		}
+		_, err = output.Write(content)
+		return err
+	}
+	finish := func() error {
+		end := "\n"
+		if array {
+			end = "\n]\n"
+			if written == 0 {
+				end = "[]\n"
+			}
+		} else if written == 0 {
+			end = ""
+		}
+		_, err := output.WriteString(end)
+		return err
+	}
+	return write, finish
+}
+
+// csvWriter returns the functions to write the documents as CSV, with a cell per column
+func csvWriter(output *bufio.Writer, columns []Column) (func(map[string]interface{}) error, func() error) {
+	writer := csv.NewWriter(output)
+	headerWritten := false
+	writeHeader := func() error {
+		if headerWritten {
+			return nil
+		}
+		headerWritten = true
+		header := make([]string, len(columns))
+		for i, column := range columns {
+			header[i] = column.Header
+		}
+		return writer.Write(header)
+	}
+
+	write := func(document map[string]interface{}) error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			value, found := bsonutil.GetField(document, column.Field)
+			if !found {
+				continue
+			}
+			text, err := bsonutil.FormatValue(value)
+			if err != nil {
+				return err
+			}
+			row[i] = text
+		}
+		return writer.Write(row)
+	}
+	finish := func() error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return write, finish
+}
+
+// documentColumns returns a column per field of the first level of the documents, with _id first
+func documentColumns(documents []map[string]interface{}) []Column {
+	fields := bsonutil.SortedFields(documents...)
+	columns := make([]Column, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, Column{Field: field, Header: field})
+	}
+	return columns
+}
+
+// columnTypes returns the columns with the Type of their values, so Import reads them back with the same types.
+// The columns with a Type keep it, and the columns with values of several types use TypeAuto
+func columnTypes(documents []map[string]interface{}, columns []Column) []Column {
+	typed := make([]Column, len(columns))
+	for i, column := range columns {
+		typed[i] = column
+		if column.Type != TypeAuto {
+			continue
+		}
+		for _, document := range documents {
+			value, found := bsonutil.GetField(document, column.Field)
+			if !found || value == nil {
+				continue
+			}
+			valueType := valueType(value)
+			if typed[i].Type != TypeAuto && typed[i].Type != valueType {
+				typed[i].Type = TypeAuto
+				break
+			}
+			typed[i].Type = valueType
+		}
+	}
+	return typed
+}
@@ -0,0 +1,99 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	tableTest   = "test"
+	objectIDHex = "5f1d7f3e9d3b2a1c4e8b4567"
+)
+
+func documentsTest() []map[string]interface{} {
+	id, _ := primitive.ObjectIDFromHex(objectIDHex)
+	return []map[string]interface{}{
+		{"name": "a", "_id": id, "created": primitive.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "address": map[string]interface{}{"city": "x"}},
+		{"_id": "2", "amount": int32(3)},
+	}
+}
+
+func exportMock(documents []map[string]interface{}) *database.DatabaseInterfaceMock {
+	return &database.DatabaseInterfaceMock{
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			return documents, nil
+		},
+	}
+}
+
+func TestExportSuccessNDJSON(t *testing.T) {
+	var output bytes.Buffer
+	var reports []int
+	count, err := Export(context.Background(), exportMock(documentsTest()), tableTest, &output, Options{BatchSize: 1, Progress: func(documents int) {
+		reports = append(reports, documents)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int{1, 2}, reports)
+	assert.Equal(t, `{"_id":{"$oid":"5f1d7f3e9d3b2a1c4e8b4567"},"address":{"city":"x"},"created":{"$date":"2024-01-02T03:04:05Z"},"name":"a"}`+"\n"+
+		`{"_id":"2","amount":3}`+"\n", output.String())
+}
+
+func TestExportSuccessJSON(t *testing.T) {
+	var output bytes.Buffer
+	_, err := Export(context.Background(), exportMock(documentsTest()[1:]), tableTest, &output, Options{Format: FormatJSON, Canonical: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n{\"_id\":\"2\",\"amount\":{\"$numberInt\":\"3\"}}\n]\n", output.String())
+
+	output.Reset()
+	_, err = Export(context.Background(), exportMock(nil), tableTest, &output, Options{Format: FormatJSON})
+	assert.NoError(t, err)
+	assert.Equal(t, "[]\n", output.String())
+}
+
+func TestExportSuccessCSV(t *testing.T) {
+	var output bytes.Buffer
+	_, err := Export(context.Background(), exportMock(documentsTest()), tableTest, &output, Options{Format: FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, "_id,address,amount,created,name\n"+
+		objectIDHex+",\"{\"\"city\"\":\"\"x\"\"}\",,2024-01-02T03:04:05Z,a\n"+
+		"2,,3,,\n", output.String())
+
+	output.Reset()
+	_, err = Export(context.Background(), exportMock(documentsTest()), tableTest, &output, Options{Format: FormatCSV, Columns: []Column{
+		{Field: "name", Header: "Name"},
+		{Field: "address.city", Header: "City"},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Name,City\na,x\n,\n", output.String())
+}
+
+func TestExportFailed(t *testing.T) {
+	var inputError *libraryErrors.InputError
+	_, err := Export(context.Background(), exportMock(nil), tableTest, &bytes.Buffer{}, Options{Format: "xml"})
+	assert.ErrorAs(t, err, &inputError)
+	_, err = Export(context.Background(), exportMock(nil), tableTest, &bytes.Buffer{}, Options{Columns: []Column{{Header: "a"}}})
+	assert.ErrorAs(t, err, &inputError)
+
+	mock := &database.DatabaseInterfaceMock{
+		FindManyFunc: func(table string, timeout int64, filter map[string]interface{}) ([]map[string]interface{}, error) {
+			return nil, &libraryErrors.ConnectionError{Db: "test"}
+		},
+	}
+	var connectionError *libraryErrors.ConnectionError
+	_, err = Export(context.Background(), mock, tableTest, &bytes.Buffer{}, Options{})
+	assert.ErrorAs(t, err, &connectionError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count, err := Export(ctx, exportMock(documentsTest()), tableTest, &bytes.Buffer{}, Options{BatchSize: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, count)
+}
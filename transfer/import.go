@@ -0,0 +1,162 @@
+package transfer
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Import reads the documents from the input and inserts them into a table with InsertMany, in batches
+// ctx: It is the context of the import. It is checked between batches
+// db: It is the database with the table
+// table: It is the table to fill
+// input: It is the source of the documents, in the format of the Options
+// options: They are the format, the columns and the rest of Options
+// It returns the number of documents inserted and an error. The batches inserted before an error are kept
+func Import(ctx context.Context, db database.DatabaseInterface, table string, input io.Reader, options Options) (int, error) {
+	options, err := options.withDefaults()
+	if err != nil {
+		return 0, err
+	}
+	var next func() (map[string]interface{}, error)
+	switch options.Format {
+	case FormatCSV:
+		next, err = csvReader(input, options.Columns)
+	case FormatJSON:
+		next, err = jsonReader(input, true)
+	default:
+		next, err = jsonReader(input, false)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	inserted := 0
+	batch := make([]map[string]interface{}, 0, options.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := db.InsertMany(table, options.Timeout, batch); err != nil {
+			return err
+		}
+		inserted += len(batch)
+		batch = batch[:0]
+		options.report(inserted)
+		return nil
+	}
+
+	for {
+		document, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return inserted, err
+		}
+		batch = append(batch, document)
+		if len(batch) == options.BatchSize {
+			if err := flush(); err != nil {
+				return inserted, err
+			}
+		}
+	}
+	return inserted, flush()
+}
+
+// jsonReader returns the function that reads the next document of NDJSON or of a JSON array. It returns io.EOF
+// after the last document
+func jsonReader(input io.Reader, array bool) (func() (map[string]interface{}, error), error) {
+	decoder := json.NewDecoder(input)
+	if array {
+		token, err := decoder.Token()
+		if err != nil || token != json.Delim('[') {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(documentMessage, 0, err)}
+		}
+	}
+	index := 0
+	return func() (map[string]interface{}, error) {
+		if array && !decoder.More() {
+			return nil, io.EOF
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) && !array {
+				return nil, io.EOF
+			}
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(documentMessage, index, err)}
+		}
+		var document map[string]interface{}
+		if err := bson.UnmarshalExtJSON(raw, false, &document); err != nil {
+			return nil, &libraryErrors.InputError{Message: fmt.Sprintf(documentMessage, index, err)}
+		}
+		index++
+		return document, nil
+	}, nil
+}
+
+// csvReader returns the function that reads the next row of the CSV as a document. The columns are matched with
+// the header by their Header, and the columns of the header without Column are skipped. Without columns, every
+// column of the header is imported with the type inferred
+func csvReader(input io.Reader, columns []Column) (func() (map[string]interface{}, error), error) {
+	reader := csv.NewReader(input)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &libraryErrors.InputError{Message: headerMessage}
+	}
+	if err != nil {
+		return nil, &libraryErrors.InputError{Message: err.Error()}
+	}
+
+	byHeader := make(map[string]Column, len(columns))
+	for _, column := range columns {
+		byHeader[column.Header] = column
+	}
+	mapped := make([]Column, len(header))
+	for i, name := range header {
+		column, found := byHeader[name]
+		if !found {
+			if len(columns) > 0 {
+				continue
+			}
+			column = Column{Field: name, Header: name}
+		}
+		mapped[i] = column
+	}
+
+	row := 0
+	return func() (map[string]interface{}, error) {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, &libraryErrors.InputError{Message: err.Error()}
+		}
+		row++
+		document := make(map[string]interface{}, len(record))
+		for i, text := range record {
+			if mapped[i].Field == "" {
+				continue
+			}
+			value, assign, err := parseValue(text, mapped[i].Type)
+			if err != nil {
+				return nil, &libraryErrors.InputError{Message: fmt.Sprintf(valueMessage, text, mapped[i].Header, row, err)}
+			}
+			if assign {
+				setField(document, mapped[i].Field, value)
+			}
+		}
+		return document, nil
+	}, nil
+}
@@ -0,0 +1,143 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cristianat98/dbclientgo/database"
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func importMock(batches *[][]map[string]interface{}) *database.DatabaseInterfaceMock {
+	return &database.DatabaseInterfaceMock{
+		InsertManyFunc: func(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+			*batches = append(*batches, append([]map[string]interface{}(nil), data...))
+			return data, nil
+		},
+	}
+}
+
+func TestImportSuccessNDJSON(t *testing.T) {
+	var batches [][]map[string]interface{}
+	var reports []int
+	input := `{"_id":{"$oid":"5f1d7f3e9d3b2a1c4e8b4567"},"created":{"$date":"2024-01-02T03:04:05Z"}}` + "\n\n{\"a\":1}\n{\"a\":2}\n"
+	count, err := Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(input), Options{BatchSize: 2, Progress: func(documents int) {
+		reports = append(reports, documents)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []int{2, 3}, reports)
+	assert.Len(t, batches, 2)
+	assert.IsType(t, primitive.ObjectID{}, batches[0][0]["_id"])
+	assert.IsType(t, primitive.DateTime(0), batches[0][0]["created"])
+}
+
+func TestImportSuccessJSONRoundTrip(t *testing.T) {
+	var output bytes.Buffer
+	_, err := Export(context.Background(), exportMock(documentsTest()), tableTest, &output, Options{Format: FormatJSON})
+	assert.NoError(t, err)
+
+	var batches [][]map[string]interface{}
+	count, err := Import(context.Background(), importMock(&batches), tableTest, &output, Options{Format: FormatJSON})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, documentsTest()[0]["_id"], batches[0][0]["_id"])
+	assert.Equal(t, documentsTest()[0]["created"], batches[0][0]["created"])
+	assert.Equal(t, documentsTest()[1], batches[0][1])
+}
+
+func TestImportSuccessCSV(t *testing.T) {
+	var batches [][]map[string]interface{}
+	input := "_id,Name,City,amount,zip,ignored\n" + objectIDHex + ",a,x,3,08001,y\n2,,,1.5,,\n"
+	count, err := Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(input), Options{Format: FormatCSV, Columns: []Column{
+		{Field: "_id"},
+		{Field: "name", Header: "Name", Type: TypeString},
+		{Field: "address.city", Header: "City"},
+		{Field: "amount"},
+		{Field: "zip", Type: TypeString},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	id, _ := primitive.ObjectIDFromHex(objectIDHex)
+	assert.Equal(t, map[string]interface{}{"_id": id, "name": "a", "address": map[string]interface{}{"city": "x"}, "amount": int64(3), "zip": "08001"}, batches[0][0])
+	assert.Equal(t, map[string]interface{}{"_id": int64(2), "amount": 1.5}, batches[0][1])
+}
+
+func TestImportSuccessCSVRoundTrip(t *testing.T) {
+	id, _ := primitive.ObjectIDFromHex(objectIDHex)
+	documents := []map[string]interface{}{
+		{"_id": id, "zip": "01234", "flag": "true", "code": objectIDHex, "amount": int64(3), "tags": primitive.A{"x"}},
+		{"_id": primitive.NewObjectID(), "zip": "1.50", "flag": "no", "code": "abc", "amount": int64(4), "tags": primitive.A{}},
+		{"_id": primitive.NewObjectID(), "created": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	var output bytes.Buffer
+	var columns []Column
+	_, err := Export(context.Background(), exportMock(documents), tableTest, &output, Options{Format: FormatCSV, Mapping: func(mapping []Column) {
+		columns = mapping
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Column{
+		{Field: "_id", Header: "_id", Type: TypeObjectID},
+		{Field: "amount", Header: "amount", Type: TypeInt},
+		{Field: "code", Header: "code", Type: TypeString},
+		{Field: "created", Header: "created", Type: TypeDate},
+		{Field: "flag", Header: "flag", Type: TypeString},
+		{Field: "tags", Header: "tags", Type: TypeJSON},
+		{Field: "zip", Header: "zip", Type: TypeString},
+	}, columns)
+
+	var batches [][]map[string]interface{}
+	_, err = Import(context.Background(), importMock(&batches), tableTest, &output, Options{Format: FormatCSV, Columns: columns})
+	assert.NoError(t, err)
+	assert.Equal(t, documents, batches[0])
+}
+
+func TestImportSuccessCSVInferred(t *testing.T) {
+	var batches [][]map[string]interface{}
+	input := "flag,date,tags,text\ntrue,2024-01-02T03:04:05Z,\"[\"\"x\"\"]\",hello\n"
+	_, err := Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(input), Options{Format: FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, true, batches[0][0]["flag"])
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), batches[0][0]["date"])
+	assert.Equal(t, primitive.A{"x"}, batches[0][0]["tags"])
+	assert.Equal(t, "hello", batches[0][0]["text"])
+
+	batches = nil
+	input = "zip,price,exponent,count\n01234,1.50,1e3,-7\n"
+	_, err = Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(input), Options{Format: FormatCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"zip": "01234", "price": "1.50", "exponent": "1e3", "count": int64(-7)}, batches[0][0])
+}
+
+func TestImportFailed(t *testing.T) {
+	var batches [][]map[string]interface{}
+	var inputError *libraryErrors.InputError
+
+	count, err := Import(context.Background(), importMock(&batches), tableTest, strings.NewReader("{\"a\":1}\n{\"a\":\n"), Options{BatchSize: 1})
+	assert.ErrorAs(t, err, &inputError)
+	assert.Equal(t, 1, count)
+
+	_, err = Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(`{"a":1}`), Options{Format: FormatJSON})
+	assert.ErrorAs(t, err, &inputError)
+
+	_, err = Import(context.Background(), importMock(&batches), tableTest, strings.NewReader(""), Options{Format: FormatCSV})
+	assert.ErrorAs(t, err, &inputError)
+
+	_, err = Import(context.Background(), importMock(&batches), tableTest, strings.NewReader("a\nx\n"), Options{Format: FormatCSV, Columns: []Column{{Field: "a", Type: TypeInt}}})
+	assert.ErrorAs(t, err, &inputError)
+
+	mock := &database.DatabaseInterfaceMock{
+		InsertManyFunc: func(table string, timeout int64, data []map[string]interface{}) ([]map[string]interface{}, error) {
+			return nil, &libraryErrors.AlreadyExistError{}
+		},
+	}
+	var alreadyExistError *libraryErrors.AlreadyExistError
+	count, err = Import(context.Background(), mock, tableTest, strings.NewReader(`{"a":1}`), Options{})
+	assert.ErrorAs(t, err, &alreadyExistError)
+	assert.Equal(t, 0, count)
+}
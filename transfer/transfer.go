@@ -0,0 +1,119 @@
+package transfer
+
+import (
+	"fmt"
+
+	libraryErrors "github.com/cristianat98/dbclientgo/errors"
+)
+
+// Format is the format of the exported documents
+type Format string
+
+const (
+	// FormatNDJSON writes a document of Extended JSON per line
+	FormatNDJSON Format = "ndjson"
+	// FormatJSON writes an array of documents of Extended JSON
+	FormatJSON Format = "json"
+	// FormatCSV writes a row per document and a column per field
+	FormatCSV Format = "csv"
+)
+
+// Type is the type of the values of a CSV column
+type Type string
+
+const (
+	// TypeAuto infers the type from the value: bool, integer, double, date (RFC 3339), ObjectID (24 hexadecimal
+	// characters), Extended JSON (documents and arrays) or string. The numbers are only inferred when they are
+	// written as Export writes them, so 01234 or 1.50 are strings
+	TypeAuto     Type = ""
+	TypeString   Type = "string"
+	TypeInt      Type = "int"
+	TypeDouble   Type = "double"
+	TypeBool     Type = "bool"
+	TypeDate     Type = "date"
+	TypeObjectID Type = "objectid"
+	TypeJSON     Type = "json"
+)
+
+// Column maps a field of the documents to a column of the CSV. The empty cells are skipped on import, whatever
+// the Type, because Export writes them for the missing and null fields
+// Field: It is the field of the documents. The fields of embedded documents are separated by dots (address.city)
+// Header: It is the header of the column (the Field by default)
+// Type: It is the type of the values when they are imported (inferred by default)
+type Column struct {
+	Field  string
+	Header string
+	Type   Type
+}
+
+// Options is the structure to define the behaviour of Export and Import. The values that are empty use the
+// default ones
+// Format: It is the format of the documents (ndjson by default)
+// Columns: They are the columns of the CSV. By default, Export uses every field of the first level, with _id
+// first, and Import uses the header of the CSV with the types inferred. When they are defined, Import skips the
+// columns of the header without Column
+// Canonical: It writes canonical Extended JSON, that also keeps the type of the numbers, instead of relaxed
+// Filter: It is the filter of the exported documents
+// BatchSize: It is the number of documents per InsertMany and between progress reports (500 by default)
+// Timeout: It is the timeout of each operation in seconds (10 by default)
+// Progress: It is called with the number of documents processed after each batch and at the end
+// Mapping: It is called by the CSV Export with the columns and the Type of their values, to use them as the Columns
+// of Import and read the values back with the same types instead of inferring them
+type Options struct {
+	Format    Format
+	Columns   []Column
+	Canonical bool
+	Filter    map[string]interface{}
+	BatchSize int
+	Timeout   int64
+	Progress  func(documents int)
+	Mapping   func(columns []Column)
+}
+
+// withDefaults validates the Options and fills the empty values with the default ones
+func (options Options) withDefaults() (Options, error) {
+	switch options.Format {
+	case "":
+		options.Format = FormatNDJSON
+	case FormatNDJSON, FormatJSON, FormatCSV:
+	default:
+		return options, &libraryErrors.InputError{Message: fmt.Sprintf(formatMessage, options.Format)}
+	}
+	if options.BatchSize < 0 {
+		return options, &libraryErrors.InputError{Message: fmt.Sprintf(batchSizeMessage, options.BatchSize)}
+	}
+	if options.Timeout < 0 {
+		return options, &libraryErrors.InputError{Message: fmt.Sprintf(timeoutMessage, options.Timeout)}
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+
+	columns := make([]Column, len(options.Columns))
+	for i, column := range options.Columns {
+		if column.Field == "" {
+			return options, &libraryErrors.InputError{Message: fmt.Sprintf(columnMessage, i)}
+		}
+		switch column.Type {
+		case TypeAuto, TypeString, TypeInt, TypeDouble, TypeBool, TypeDate, TypeObjectID, TypeJSON:
+		default:
+			return options, &libraryErrors.InputError{Message: fmt.Sprintf(typeMessage, column.Type, column.Field)}
+		}
+		if column.Header == "" {
+			column.Header = column.Field
+		}
+		columns[i] = column
+	}
+	options.Columns = columns
+	return options, nil
+}
+
+// report calls Progress, if it is defined
+func (options Options) report(documents int) {
+	if options.Progress != nil {
+		options.Progress(documents)
+	}
+}
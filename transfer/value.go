@@ -0,0 +1,115 @@
+package transfer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// objectIDPattern matches the hexadecimal representation of an ObjectID
+var objectIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+// setField assigns the value to a field, creating the embedded documents of the dots
+func setField(document map[string]interface{}, field string, value interface{}) {
+	keys := strings.Split(field, ".")
+	for _, key := range keys[:len(keys)-1] {
+		embedded, ok := document[key].(map[string]interface{})
+		if !ok {
+			embedded = make(map[string]interface{})
+			document[key] = embedded
+		}
+		document = embedded
+	}
+	document[keys[len(keys)-1]] = value
+}
+
+// parseValue converts the text of a CSV cell to the type of the column. With TypeAuto, the type is inferred. The
+// empty values are skipped in every type, because Export writes them for the missing and null fields
+// It returns the value, if the value must be assigned and an error
+func parseValue(text string, columnType Type) (interface{}, bool, error) {
+	if text == "" {
+		return nil, false, nil
+	}
+	switch columnType {
+	case TypeString:
+		return text, true, nil
+	case TypeInt:
+		value, err := strconv.ParseInt(text, 10, 64)
+		return value, err == nil, err
+	case TypeDouble:
+		value, err := strconv.ParseFloat(text, 64)
+		return value, err == nil, err
+	case TypeBool:
+		value, err := strconv.ParseBool(text)
+		return value, err == nil, err
+	case TypeDate:
+		value, err := time.Parse(time.RFC3339Nano, text)
+		return value, err == nil, err
+	case TypeObjectID:
+		value, err := primitive.ObjectIDFromHex(text)
+		return value, err == nil, err
+	case TypeJSON:
+		value, err := parseJSONValue(text)
+		return value, err == nil, err
+	}
+
+	switch {
+	case text == "true" || text == "false":
+		return text == "true", true, nil
+	case objectIDPattern.MatchString(text):
+		value, err := primitive.ObjectIDFromHex(text)
+		return value, err == nil, err
+	case strings.HasPrefix(text, "{") || strings.HasPrefix(text, "["):
+		if value, err := parseJSONValue(text); err == nil {
+			return value, true, nil
+		}
+	}
+	// The numbers are only inferred when they are written as Export writes them, so texts like 01234 are kept
+	if value, err := strconv.ParseInt(text, 10, 64); err == nil && strconv.FormatInt(value, 10) == text {
+		return value, true, nil
+	}
+	if value, err := strconv.ParseFloat(text, 64); err == nil && strconv.FormatFloat(value, 'g', -1, 64) == text {
+		return value, true, nil
+	}
+	if value, err := time.Parse(time.RFC3339Nano, text); err == nil {
+		return value, true, nil
+	}
+	return text, true, nil
+}
+
+// valueType returns the Type that imports the value back with its type. The documents, the arrays and the rest
+// of the values without their own Type use TypeJSON
+func valueType(value interface{}) Type {
+	switch value.(type) {
+	case string:
+		return TypeString
+	case int, int32, int64:
+		return TypeInt
+	case float64:
+		return TypeDouble
+	case bool:
+		return TypeBool
+	case primitive.DateTime, time.Time:
+		return TypeDate
+	case primitive.ObjectID:
+		return TypeObjectID
+	default:
+		return TypeJSON
+	}
+}
+
+// parseJSONValue parses an Extended JSON value (document, array or scalar)
+func parseJSONValue(text string) (interface{}, error) {
+	var wrapper struct {
+		Value interface{} `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(fmt.Sprintf(`{"v":%s}`, text)), false, &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.Value, nil
+}